@@ -1,114 +1,272 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
-	"os"
+	"math/rand"
 	"runtime"
 	"time"
 
 	gws "github.com/MorganR/go-wordle-solver/lib"
 	"github.com/spf13/cobra"
+	"golang.org/x/exp/slices"
 )
 
 const (
-	maxGuesses int = 128
+	// The default maximum number of guesses for each benchmark game. This is high enough that games
+	// only fail if the guesser gets stuck.
+	defaultBenchMaxGuesses int = 128
 )
 
 var BenchListPath string
+var BenchDays string
+var BenchMaxGuesses int
+var BenchSeed int64
 
 var maxThreads = runtime.NumCPU()
 
 func init() {
-	benchCmd.Flags().StringVarP(&BenchListPath, "bench_list", "b", "../data/1000-improved-words-shuffled.txt", "Path to a list of objective words to benchmark this algorithm against.")
+	benchCmd.Flags().StringVarP(&BenchListPath, "bench_list", "b", "1000-improved-shuffled", "The name of a built-in word list, or the path to a list of objective words to benchmark this algorithm against. Defaults to the word bank itself when it's a built-in list of equations, e.g. nerdle.")
+	benchCmd.Flags().IntVar(&BenchMaxGuesses, "max_guesses", defaultBenchMaxGuesses, "The maximum number of guesses for each game, e.g. 6 to match Wordle. Games that aren't solved within this many guesses count as failures.")
+	benchCmd.Flags().Int64Var(&BenchSeed, "seed", 0, "The seed used to pick the lies that are added to results with the fibble guesser. If 0, the current time is used.")
+	benchCmd.Flags().StringVar(&BenchDays, "days", "", "Benchmark against the daily puzzles for this inclusive range of dates (YYYY-MM-DD..YYYY-MM-DD) instead of --bench_list.")
+	addDailyFlags(benchCmd)
 	rootCmd.AddCommand(benchCmd)
 }
 
 var benchCmd = &cobra.Command{
 	Use:   "bench",
 	Short: "Benchmarks an algorithm against a given word list.",
+	Long: `Benchmarks an algorithm against a given word list.
+
+If --length is not set, this benchmarks each word length that is present in both the word bank and
+the benchmark list.
+
+With --days, this instead benchmarks the daily puzzles for each date in the range.
+
+Each game has at most --max_guesses guesses, and the failure rate at that budget is reported.`,
 	RunE: func(cmd *cobra.Command, args []string) error {
-		initRoot()
-		f, err := os.Open(BenchListPath)
+		if BenchMaxGuesses < 1 {
+			return errors.New("--max_guesses must be at least 1.")
+		}
+		err := initWordBanks()
 		if err != nil {
 			return err
 		}
-		benchBank, err := gws.WordBankFromReader(f)
+		if BenchDays != "" {
+			return benchDays(BenchDays)
+		}
+		benchListPath := BenchListPath
+		if !cmd.Flags().Changed("bench_list") && gws.IsBuiltinEquationList(WordBankPath) {
+			benchListPath = WordBankPath
+		}
+		f, err := openWordList(benchListPath)
 		if err != nil {
 			return err
 		}
-		benchWords := benchBank.Words()
-
-		start := time.Now()
-		benchLen := benchWords.Len()
-		countNumGuesses := make([]int, maxGuesses)
-		results := make(chan gws.GameResult, maxThreads)
-		objectives := make(chan gws.Word, maxThreads)
-		errs := make(chan error, maxThreads)
-		done := make(chan bool)
-		benchThreads := maxThreads - 1
-		for i := 0; i < benchThreads; i++ {
-			go benchGuesser(objectives, results, errs, done, guesser.Copy())
-		}
-		go collectResults(results, done, countNumGuesses)
-		for i := 0; i < benchLen; i++ {
-			objective := benchWords.At(i)
-			select {
-			case err = <-errs:
-				break
-			case objectives <- objective:
-				continue
+		defer f.Close()
+		benchBanks, err := gws.MultiLengthWordBankFromReaderWithNormalizer(f, normalizer)
+		if err != nil {
+			return err
+		}
+
+		lengths := []uint8{WordLength}
+		if WordLength == 0 {
+			lengths = benchLengths(&benchBanks)
+			if len(lengths) == 0 {
+				return fmt.Errorf("The benchmark list has no words of the same length as the word bank (%v).", wordBanks.WordLengths())
 			}
 		}
-		close(objectives)
-		// Wait for bench threads.
-		for dones := 0; dones < benchThreads; {
-			select {
-			case err = <-errs:
-				// Continue to finish the other routines.
-			case <-done:
-				dones++
+		for _, length := range lengths {
+			benchBank, err := benchBanks.ForLength(length)
+			if err != nil {
+				return err
+			}
+			err = selectWordLength(length)
+			if err != nil {
+				return err
+			}
+			if len(lengths) > 1 {
+				fmt.Printf("Word length: %v\n", length)
+			}
+			benchWords := benchBank.Words()
+			err = runBench(&benchWords)
+			if err != nil {
+				return err
 			}
 		}
-		close(results)
-		// Wait for the collector.
-		<-done
+		return nil
+	},
+}
+
+// benchDays benchmarks the daily puzzles for the given range of dates.
+func benchDays(dateRange string) error {
+	dates, err := parseDateRange(dateRange)
+	if err != nil {
+		return err
+	}
+	puzzles, err := initDailyPuzzles(WordLength)
+	if err != nil {
+		return err
+	}
+	objectives := make([]string, len(dates))
+	for i, date := range dates {
+		objective, err := puzzles.ForDate(date)
 		if err != nil {
 			return err
 		}
-		end := time.Now()
-		elapsed := end.Sub(start)
-		fmt.Printf("Benchmark completed in %s.\n", elapsed)
+		objectives[i] = objective.String()
+	}
+	benchBank, err := gws.WordBankFromSliceWithNormalizer(objectives, normalizer)
+	if err != nil {
+		return err
+	}
+	if err := selectWordLength(benchBank.WordLength()); err != nil {
+		return err
+	}
+	fmt.Printf("Benchmarking %v daily puzzles from %s to %s.\n", len(dates), dates[0].Format(dateFormat), dates[len(dates)-1].Format(dateFormat))
+	benchWords := benchBank.Words()
+	return runBench(&benchWords)
+}
 
-		maxGuessIndex := findLastNonZeroIndex(countNumGuesses)
-		countNumGuesses = countNumGuesses[0:maxGuessIndex]
-		printNumGuessResults(countNumGuesses)
+// benchLengths returns the word lengths present in both the benchmark list and the word bank.
+func benchLengths(benchBanks *gws.MultiLengthWordBank) []uint8 {
+	bankLengths := wordBanks.WordLengths()
+	lengths := make([]uint8, 0, len(bankLengths))
+	for _, length := range benchBanks.WordLengths() {
+		if slices.Contains(bankLengths, length) {
+			lengths = append(lengths, length)
+		}
+	}
+	return lengths
+}
 
-		return nil
-	},
+// runBench plays a game for each of the given objectives using the current guesser, and prints
+// the results.
+func runBench(benchWords *gws.PossibleWords) error {
+	var err error
+	start := time.Now()
+	seed := BenchSeed
+	if seed == 0 {
+		seed = time.Now().UnixNano()
+	}
+	if Guesser == "fibble" {
+		fmt.Printf("Picking lies with seed %v.\n", seed)
+	}
+	games := benchGames(benchWords)
+	countNumGuesses := make([]int, BenchMaxGuesses)
+	numFailures := 0
+	results := make(chan benchResult, maxThreads)
+	objectives := make(chan benchGame, maxThreads)
+	errs := make(chan error, maxThreads)
+	done := make(chan bool)
+	// Leave a thread for the collector, but always run at least one benchmark thread.
+	benchThreads := maxThreads - 1
+	if benchThreads < 1 {
+		benchThreads = 1
+	}
+	for i := 0; i < benchThreads; i++ {
+		go benchGuesser(objectives, results, errs, done, guesser.Copy())
+	}
+	go collectResults(results, done, countNumGuesses, &numFailures)
+sendGames:
+	for i, game := range games {
+		select {
+		case err = <-errs:
+			break sendGames
+		case objectives <- benchGame{game, seed + int64(i)}:
+			continue
+		}
+	}
+	close(objectives)
+	// Wait for bench threads.
+	for dones := 0; dones < benchThreads; {
+		select {
+		case err = <-errs:
+			// Continue to finish the other routines.
+		case <-done:
+			dones++
+		}
+	}
+	close(results)
+	// Wait for the collector.
+	<-done
+	if err != nil {
+		return err
+	}
+	end := time.Now()
+	elapsed := end.Sub(start)
+	fmt.Printf("Benchmark completed in %s.\n", elapsed)
+
+	maxGuessIndex := findLastNonZeroIndex(countNumGuesses)
+	countNumGuesses = countNumGuesses[0 : maxGuessIndex+1]
+	printNumGuessResults(countNumGuesses, numFailures)
+
+	return nil
+}
+
+// benchGames returns the objectives for each game in the benchmark.
+//
+// When using the xordle guesser, each word is paired with the next word in the list that has no
+// letters in common with it.
+func benchGames(benchWords *gws.PossibleWords) [][]gws.Word {
+	benchLen := benchWords.Len()
+	games := make([][]gws.Word, 0, benchLen)
+	for i := 0; i < benchLen; i++ {
+		objective := benchWords.At(i)
+		if Guesser != "xordle" {
+			games = append(games, []gws.Word{objective})
+			continue
+		}
+		for j := 1; j < benchLen; j++ {
+			partner := benchWords.At((i + j) % benchLen)
+			if gws.IsValidXordlePair(objective, partner) {
+				games = append(games, []gws.Word{objective, partner})
+				break
+			}
+		}
+	}
+	return games
+}
+
+// benchResult is the result of a single game in a benchmark.
+type benchResult struct {
+	objectives []gws.Word
+	result     gws.GameResult
+}
+
+// benchGame is a single game in a benchmark.
+type benchGame struct {
+	objectives []gws.Word
+	// The seed for any randomness in the game, so that each game is reproducible regardless of
+	// which thread plays it.
+	seed int64
 }
 
-func benchGuesser(objectives <-chan gws.Word, results chan<- gws.GameResult, errs chan<- error, done chan<- bool, guesser gws.Guesser) {
-	for objective, more := <-objectives; more; objective, more = <-objectives {
-		result, err := gws.PlayGameWithGuesser(objective, maxGuesses, guesser)
+func benchGuesser(games <-chan benchGame, results chan<- benchResult, errs chan<- error, done chan<- bool, guesser gws.Guesser) {
+	for game, more := <-games; more; game, more = <-games {
+		result, err := playGame(game.objectives, BenchMaxGuesses, guesser, rand.New(rand.NewSource(game.seed)))
 		if err != nil {
 			errs <- err
 			done <- true
 			return
 		}
-		if result.Status != gws.GameSuccess {
-			errs <- fmt.Errorf("Failed to guess %s during benchmark.\n", objective)
-			printGuesses(result.Turns)
-			done <- true
-			return
-		}
-		results <- result
+		results <- benchResult{game.objectives, result}
 	}
 	done <- true
 }
 
-func collectResults(results <-chan gws.GameResult, done chan<- bool, countNumGuesses []int) {
-	for result, more := <-results; more; result, more = <-results {
-		numGuesses := len(result.Turns)
+// collectResults counts the number of guesses for each game, and prints the guesses for each game
+// that failed.
+func collectResults(results <-chan benchResult, done chan<- bool, countNumGuesses []int, numFailures *int) {
+	for br, more := <-results; more; br, more = <-results {
+		if br.result.Status != gws.GameSuccess {
+			*numFailures++
+			fmt.Printf("Failed to guess %v within %v guesses.\n", br.objectives, BenchMaxGuesses)
+			printGuesses(br.result.Turns)
+			continue
+		}
+		numGuesses := len(br.result.Turns)
 		countNumGuesses[numGuesses-1] = countNumGuesses[numGuesses-1] + 1
 	}
 	done <- true
@@ -123,7 +281,10 @@ func findLastNonZeroIndex(counts []int) int {
 	return 0
 }
 
-func printNumGuessResults(counts []int) {
+// printNumGuessResults prints how many games were solved with each number of guesses, and the
+// failure rate. The average only includes the games that were solved, and is labelled as such if
+// any games failed.
+func printNumGuessResults(counts []int, numFailures int) {
 	fmt.Println("Num guesses | Count")
 	totalGuesses := 0
 	runningTotal := 0
@@ -133,5 +294,17 @@ func printNumGuessResults(counts []int) {
 		totalGuesses += n
 		runningTotal += (i + 1) * n
 	}
-	fmt.Printf("Average: %v +/- %v\n", float32(runningTotal)/float32(totalGuesses), 0)
+	fmt.Println("--|---")
+	fmt.Printf("Failed | %v\n", numFailures)
+	averageLabel := "Average"
+	if numFailures > 0 {
+		averageLabel = "Average of solved games"
+	}
+	if totalGuesses == 0 {
+		fmt.Println("No games were solved.")
+	} else {
+		fmt.Printf("%s: %v +/- %v\n", averageLabel, float32(runningTotal)/float32(totalGuesses), 0)
+	}
+	numGames := totalGuesses + numFailures
+	fmt.Printf("Failure rate within %v guesses: %.2f%% (%v of %v games)\n", BenchMaxGuesses, 100*float32(numFailures)/float32(numGames), numFailures, numGames)
 }
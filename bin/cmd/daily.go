@@ -0,0 +1,88 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	gws "github.com/MorganR/go-wordle-solver/lib"
+	"github.com/spf13/cobra"
+)
+
+const dateFormat = "2006-01-02"
+
+var DailyAnswers string
+var DailySeed int64
+
+// addDailyFlags adds the flags that configure daily puzzles to the given command.
+func addDailyFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVar(&DailyAnswers, "daily_answers", "wordle-answers", "The name of a built-in word list, or the path to an ordered list of answers, to pick daily puzzles from. The built-in lists are not in Wordle's original order, so provide a list in that order to match the real puzzles.")
+	cmd.Flags().Int64Var(&DailySeed, "daily_seed", 0, "If not 0, daily puzzles are picked from the answers in an order shuffled by this seed.")
+}
+
+// initDailyPuzzles loads the daily puzzles with words of the given length.
+//
+// If length is zero, the answers must only contain words of a single length.
+func initDailyPuzzles(length uint8) (gws.DailyPuzzles, error) {
+	answers, err := loadWordBank(DailyAnswers, length)
+	if err != nil {
+		return gws.DailyPuzzles{}, err
+	}
+	if DailySeed != 0 {
+		return gws.InitShuffledDailyPuzzles(answers, DailySeed), nil
+	}
+	return gws.InitDailyPuzzles(answers), nil
+}
+
+// dailyPuzzle returns the number and objective of the daily puzzle on the given date, with words of
+// the given length.
+func dailyPuzzle(dateStr string, length uint8) (int, gws.Word, error) {
+	date, err := parseDate(dateStr)
+	if err != nil {
+		return 0, gws.Word{}, err
+	}
+	puzzles, err := initDailyPuzzles(length)
+	if err != nil {
+		return 0, gws.Word{}, err
+	}
+	number, err := puzzles.Number(date)
+	if err != nil {
+		return 0, gws.Word{}, err
+	}
+	objective, err := puzzles.ForNumber(number)
+	return number, objective, err
+}
+
+// parseDate parses a date in the form "YYYY-MM-DD".
+func parseDate(s string) (time.Time, error) {
+	date, err := time.Parse(dateFormat, s)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Invalid date %s. Expected the form YYYY-MM-DD.", s)
+	}
+	return date, nil
+}
+
+// parseDateRange parses an inclusive range of dates in the form "YYYY-MM-DD..YYYY-MM-DD", or a
+// single date.
+func parseDateRange(s string) ([]time.Time, error) {
+	startStr, endStr, isRange := strings.Cut(s, "..")
+	if !isRange {
+		endStr = startStr
+	}
+	start, err := parseDate(startStr)
+	if err != nil {
+		return nil, err
+	}
+	end, err := parseDate(endStr)
+	if err != nil {
+		return nil, err
+	}
+	if end.Before(start) {
+		return nil, fmt.Errorf("Invalid date range %s. The end must not be before the start.", s)
+	}
+	var dates []time.Time
+	for date := start; !date.After(end); date = date.AddDate(0, 0, 1) {
+		dates = append(dates, date)
+	}
+	return dates, nil
+}
@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	gws "github.com/MorganR/go-wordle-solver/lib"
+	"github.com/spf13/cobra"
+)
+
+var FilterAt []string
+var FilterNotAt []string
+var FilterCounts []string
+var FilterExclude string
+var FilterNoRepeats bool
+var FilterLimit int
+
+func init() {
+	filterCmd.Flags().StringSliceVar(&FilterAt, "at", nil, "Letters known to be at a position, as the letter followed by the position, e.g. \"s1\". Positions start from 1.")
+	filterCmd.Flags().StringSliceVar(&FilterNotAt, "not_at", nil, "Letters known not to be at a position, e.g. \"s1\". This doesn't imply that the letter is in the word.")
+	filterCmd.Flags().StringSliceVar(&FilterCounts, "count", nil, "The number of times a letter appears, as the letter, '=', and either a count or an inclusive range, e.g. \"e=2\", \"e=1..2\", \"e=..1\", or \"e=2..\".")
+	filterCmd.Flags().StringVar(&FilterExclude, "exclude", "", "Letters known not to be in the word.")
+	filterCmd.Flags().BoolVar(&FilterNoRepeats, "no_repeats", false, "Whether each letter appears at most once.")
+	filterCmd.Flags().IntVar(&FilterLimit, "limit", 100, "The maximum number of words to print. If 0, all words are printed.")
+	rootCmd.AddCommand(filterCmd)
+}
+
+var filterCmd = &cobra.Command{
+	Use:   "filter [guess:result...]",
+	Short: "Lists the possible words given some guesses and known facts.",
+	Long: `Lists the possible words given some guesses and known facts.
+
+Each guess is given with its result as a compact string, where 'g' is correct, 'y' is present but not
+here, and '-' is not present, e.g. "arise:-y--g". Other facts about the word can be added with
+flags, e.g. "--at s1 --no_repeats".`,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := initWordBanks(); err != nil {
+			return err
+		}
+		results := make([]gws.GuessResult, len(args))
+		for i, arg := range args {
+			var err error
+			if results[i], err = parseGuessResult(arg); err != nil {
+				return err
+			}
+		}
+		length := WordLength
+		if length == 0 && len(results) > 0 {
+			length = uint8(results[0].Guess.Len())
+		}
+		if err := selectWordBank(length); err != nil {
+			return err
+		}
+
+		restrictions := gws.InitWordRestrictions(wordBank.WordLength())
+		for i := range results {
+			if err := restrictions.Update(&results[i]); err != nil {
+				return err
+			}
+		}
+		if err := addFilterFlagRestrictions(&restrictions); err != nil {
+			return err
+		}
+
+		summary := restrictions.Summary()
+		fmt.Println(&summary)
+		words := wordBank.Words()
+		var matches []gws.Word
+		for i := 0; i < words.Len(); i++ {
+			if restrictions.IsSatisfiedBy(words.At(i)) {
+				matches = append(matches, words.At(i))
+			}
+		}
+		fmt.Printf("%v possible words:\n", len(matches))
+		for i, word := range matches {
+			if FilterLimit > 0 && i == FilterLimit {
+				fmt.Printf("\t... and %v more\n", len(matches)-FilterLimit)
+				break
+			}
+			fmt.Printf("\t%s\n", word)
+		}
+		return nil
+	},
+}
+
+// parseGuessResult parses a guess and its result in the form "guess:result".
+func parseGuessResult(s string) (gws.GuessResult, error) {
+	guessStr, resultStr, hasResult := strings.Cut(s, ":")
+	if !hasResult {
+		return gws.GuessResult{}, fmt.Errorf("Invalid guess %s. Expected the form guess:result, e.g. \"arise:-y--g\".", s)
+	}
+	guess := normalizer.WordFromString(guessStr)
+	letterResults, err := gws.LetterResultsFromString(resultStr)
+	if err != nil {
+		return gws.GuessResult{}, err
+	}
+	if len(letterResults) != guess.Len() {
+		return gws.GuessResult{}, fmt.Errorf("Invalid guess %s. The result must be the same length as the guess.", s)
+	}
+	return gws.GuessResult{Guess: guess, Results: letterResults}, nil
+}
+
+// addFilterFlagRestrictions adds the restrictions given by the filter command's flags.
+func addFilterFlagRestrictions(restrictions *gws.WordRestrictions) error {
+	for _, located := range []struct {
+		values []string
+		add    func(letter rune, location uint8) error
+	}{{FilterAt, restrictions.SetLetterAt}, {FilterNotAt, restrictions.SetLetterNotAt}} {
+		for _, value := range located.values {
+			letter, location, err := parseLetterPosition(value)
+			if err != nil {
+				return err
+			}
+			if err := located.add(letter, location); err != nil {
+				return err
+			}
+		}
+	}
+	for _, value := range FilterCounts {
+		if err := addLetterCount(restrictions, value); err != nil {
+			return err
+		}
+	}
+	for _, letter := range normalizer.Normalize(FilterExclude) {
+		if err := restrictions.ExcludeLetter(letter); err != nil {
+			return err
+		}
+	}
+	if FilterNoRepeats {
+		return restrictions.SetNoRepeatedLetters()
+	}
+	return nil
+}
+
+// parseLetter parses a single normalized letter.
+func parseLetter(s string) (rune, error) {
+	normalized := normalizer.Normalize(s)
+	if utf8.RuneCountInString(normalized) != 1 {
+		return 0, fmt.Errorf("Invalid letter %q.", s)
+	}
+	letter, _ := utf8.DecodeRuneInString(normalized)
+	return letter, nil
+}
+
+// parseLetterPosition parses a letter followed by a position starting from 1, e.g. "s1", and
+// returns the letter and its location starting from 0.
+func parseLetterPosition(s string) (rune, uint8, error) {
+	_, size := utf8.DecodeRuneInString(s)
+	letter, err := parseLetter(s[:size])
+	if err != nil {
+		return 0, 0, err
+	}
+	position, err := strconv.ParseUint(s[size:], 10, 8)
+	if err != nil || position == 0 {
+		return 0, 0, fmt.Errorf("Invalid letter position %s. Expected a letter followed by a position starting from 1, e.g. \"s1\".", s)
+	}
+	return letter, uint8(position - 1), nil
+}
+
+// addLetterCount parses a letter count, e.g. "e=2" or "e=1..2", and adds it to the restrictions.
+func addLetterCount(restrictions *gws.WordRestrictions, s string) error {
+	letterStr, countStr, hasCount := strings.Cut(s, "=")
+	if !hasCount {
+		return fmt.Errorf("Invalid letter count %s. Expected the form letter=count, e.g. \"e=2\" or \"e=1..2\".", s)
+	}
+	letter, err := parseLetter(letterStr)
+	if err != nil {
+		return err
+	}
+	minStr, maxStr, isRange := strings.Cut(countStr, "..")
+	if !isRange {
+		maxStr = minStr
+	}
+	parseCount := func(str string) (uint8, error) {
+		count, err := strconv.ParseUint(str, 10, 8)
+		if err != nil {
+			return 0, fmt.Errorf("Invalid letter count %s. Counts must be between 0 and 255.", s)
+		}
+		return uint8(count), nil
+	}
+	if minStr != "" {
+		count, err := parseCount(minStr)
+		if err != nil {
+			return err
+		}
+		if err := restrictions.SetLetterMinCount(letter, count); err != nil {
+			return err
+		}
+	}
+	if maxStr != "" {
+		count, err := parseCount(maxStr)
+		if err != nil {
+			return err
+		}
+		if err := restrictions.SetLetterMaxCount(letter, count); err != nil {
+			return err
+		}
+	}
+	return nil
+}
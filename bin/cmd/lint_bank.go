@@ -0,0 +1,95 @@
+package cmd
+
+import (
+	"fmt"
+
+	gws "github.com/MorganR/go-wordle-solver/lib"
+	"github.com/spf13/cobra"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+var LintAlphabet string
+
+func init() {
+	lintBankCmd.Flags().StringVarP(&LintAlphabet, "alphabet", "a", "", "The letters that words may contain. If empty, any letters are allowed.")
+	rootCmd.AddCommand(lintBankCmd)
+}
+
+var lintBankCmd = &cobra.Command{
+	Use:   "lint-bank [name or path]",
+	Short: "Checks a word list for problems, and prints statistics about it.",
+	Long: `Checks a word list for problems, and prints statistics about it.
+
+This reports duplicate words, words with letters outside the alphabet, and words that don't match
+--length (if set). Lines may contain comments starting with '#'. The list may be the name of a
+built-in word list or a file path. If none is given, this checks the word bank.`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		path := WordBankPath
+		if len(args) > 0 {
+			path = args[0]
+		}
+		var err error
+		normalizer, err = initNormalizer()
+		if err != nil {
+			return err
+		}
+		f, err := openWordList(path)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		options := gws.WordListOptions{
+			Normalizer: normalizer,
+			WordLength: WordLength,
+		}
+		if LintAlphabet != "" {
+			options.Alphabet = gws.AlphabetFromString(LintAlphabet)
+		}
+		report, err := gws.LintWordList(f, options)
+		if err != nil {
+			return err
+		}
+		printWordListReport(path, &report)
+		if len(report.Problems) > 0 {
+			return fmt.Errorf("Found %v problems in %s.", len(report.Problems), path)
+		}
+		return nil
+	},
+}
+
+func printWordListReport(path string, report *gws.WordListReport) {
+	fmt.Printf("Checked %s: %v lines, %v unique valid words, %v blank or comment lines.\n", path, report.NumLines, report.NumWords, report.NumBlankOrComment)
+
+	if len(report.Problems) > 0 {
+		fmt.Printf("Problems (%v):\n", len(report.Problems))
+		for _, problem := range report.Problems {
+			fmt.Printf("\t%s\n", problem)
+		}
+	}
+
+	fmt.Println("Word length | Count")
+	lengths := maps.Keys(report.NumWordsPerLength)
+	slices.Sort(lengths)
+	for _, length := range lengths {
+		fmt.Println("--|---")
+		fmt.Printf("%v | %v\n", length, report.NumWordsPerLength[length])
+	}
+
+	fmt.Println("Letter | Count | Average per word")
+	letters := maps.Keys(report.LetterCounts)
+	slices.SortFunc(letters, func(a, b rune) bool {
+		countA := report.LetterCounts[a]
+		countB := report.LetterCounts[b]
+		if countA != countB {
+			return countA > countB
+		}
+		return a < b
+	})
+	for _, letter := range letters {
+		count := report.LetterCounts[letter]
+		fmt.Println("--|---|---")
+		fmt.Printf("%c | %v | %.3f\n", letter, count, float32(count)/float32(report.NumWords))
+	}
+}
@@ -0,0 +1,288 @@
+package cmd
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand"
+	"strings"
+	"time"
+
+	gws "github.com/MorganR/go-wordle-solver/lib"
+	"github.com/spf13/cobra"
+)
+
+var PlayAnswers string
+var PlayAllowed string
+var PlaySeed int64
+var PlayIndex int
+var PlayDate string
+var PlayHardMode bool
+var PlayMaxGuesses int
+var PlayColor bool
+
+func init() {
+	playCmd.Flags().StringVar(&PlayAnswers, "answers", "wordle-answers", "The name of a built-in word list, or the path to a list of words, to pick the hidden word from.")
+	playCmd.Flags().StringVar(&PlayAllowed, "allowed", "wordle-words", "The name of a built-in word list, or the path to a list of words, that may be guessed. Words in --answers may always be guessed.")
+	playCmd.Flags().Int64Var(&PlaySeed, "seed", 0, "The seed used to pick a random hidden word. If 0, the current time is used.")
+	playCmd.Flags().IntVar(&PlayIndex, "index", -1, "The index of the hidden word in --answers. If negative, a random word is picked.")
+	playCmd.Flags().StringVar(&PlayDate, "date", "", "Play the daily puzzle for this date (YYYY-MM-DD) instead of a word from --answers.")
+	addDailyFlags(playCmd)
+	playCmd.Flags().BoolVar(&PlayHardMode, "hard", false, "Whether to play in hard mode, where any revealed hints must be used in subsequent guesses.")
+	playCmd.Flags().IntVar(&PlayMaxGuesses, "max_guesses", 6, "The maximum number of guesses.")
+	playCmd.Flags().BoolVar(&PlayColor, "color", true, "Whether to colour the output with ANSI escape codes.")
+	rootCmd.AddCommand(playCmd)
+}
+
+var playCmd = &cobra.Command{
+	Use:   "play",
+	Short: "Plays a game of Wordle in the terminal.",
+	Long: `Plays a game of Wordle in the terminal.
+
+A hidden word is picked from the --answers list, or is the daily puzzle for the given --date, and
+guesses are read from standard input, one per line. Each guess must be in the --answers or
+--allowed lists, or be the hidden word.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		var err error
+		normalizer, err = initNormalizer()
+		if err != nil {
+			return err
+		}
+		answers, err := loadWordBank(PlayAnswers, WordLength)
+		if err != nil {
+			return err
+		}
+		allowed, err := loadWordBank(PlayAllowed, answers.WordLength())
+		if err != nil {
+			return err
+		}
+		if PlayMaxGuesses < 1 {
+			return errors.New("--max_guesses must be at least 1.")
+		}
+
+		if PlayDate != "" {
+			if cmd.Flags().Changed("index") || cmd.Flags().Changed("seed") {
+				return errors.New("--date cannot be combined with --index or --seed.")
+			}
+			number, objective, err := dailyPuzzle(PlayDate, answers.WordLength())
+			if err != nil {
+				return err
+			}
+			game := initTerminalGame(objective, answers, allowed)
+			return game.play(cmd.InOrStdin(), number)
+		}
+
+		answerWords := answers.Words()
+		index := PlayIndex
+		if index < 0 {
+			seed := PlaySeed
+			if seed == 0 {
+				seed = time.Now().UnixNano()
+			}
+			index = rand.New(rand.NewSource(seed)).Intn(answerWords.Len())
+		} else if index >= answerWords.Len() {
+			return fmt.Errorf("--index must be less than the number of answers (%v).", answerWords.Len())
+		}
+
+		game := initTerminalGame(answerWords.At(index), answers, allowed)
+		return game.play(cmd.InOrStdin(), index)
+	},
+}
+
+// terminalGame is a game of Wordle played by a human in the terminal.
+type terminalGame struct {
+	objective    gws.Word
+	allowed      map[string]bool
+	history      []gws.GuessResult
+	restrictions gws.WordRestrictions
+}
+
+func initTerminalGame(objective gws.Word, answers, allowed *gws.WordBank) terminalGame {
+	allowedWords := map[string]bool{objective.String(): true}
+	for _, bank := range []*gws.WordBank{answers, allowed} {
+		words := bank.Words()
+		for i := 0; i < words.Len(); i++ {
+			allowedWords[words.At(i).String()] = true
+		}
+	}
+	return terminalGame{
+		objective:    objective,
+		allowed:      allowedWords,
+		restrictions: gws.InitWordRestrictions(uint8(objective.Len())),
+	}
+}
+
+func (game *terminalGame) play(in io.Reader, index int) error {
+	fmt.Printf("Guess the %v-letter word in %v guesses.\n", game.objective.Len(), PlayMaxGuesses)
+	if PlayHardMode {
+		fmt.Println("Hard mode: any revealed hints must be used in subsequent guesses.")
+	}
+	scanner := bufio.NewScanner(in)
+	won := false
+	for len(game.history) < PlayMaxGuesses && !won {
+		fmt.Printf("\nGuess %v/%v: ", len(game.history)+1, PlayMaxGuesses)
+		if !scanner.Scan() {
+			if err := scanner.Err(); err != nil {
+				return err
+			}
+			fmt.Printf("\nGame abandoned. The word was: %s\n", game.objective)
+			return nil
+		}
+		guess := normalizer.WordFromString(scanner.Text())
+		if err := game.checkGuess(guess); err != nil {
+			fmt.Println(err)
+			continue
+		}
+		result, err := gws.GetResultForGuess(game.objective, guess)
+		if err != nil {
+			return err
+		}
+		if err := game.restrictions.Update(&result); err != nil {
+			return err
+		}
+		game.history = append(game.history, result)
+		won = guess.Equal(game.objective)
+
+		fmt.Println()
+		for _, result := range game.history {
+			fmt.Println(formatResult(&result))
+		}
+		fmt.Println()
+		fmt.Print(game.formatKeyboard())
+	}
+
+	fmt.Println()
+	if won {
+		fmt.Println("You got it!")
+	} else {
+		fmt.Printf("Out of guesses. The word was: %s\n", game.objective)
+	}
+	fmt.Println()
+	fmt.Println(game.shareGrid(index, won))
+	return nil
+}
+
+// checkGuess returns an error if the guess is not allowed.
+func (game *terminalGame) checkGuess(guess gws.Word) error {
+	if guess.Len() != game.objective.Len() {
+		return fmt.Errorf("The guess must have %v letters.", game.objective.Len())
+	}
+	if !game.allowed[guess.String()] {
+		return fmt.Errorf("%s is not in the word list.", guess)
+	}
+	if PlayHardMode {
+		return gws.CheckHardModeGuess(game.history, guess)
+	}
+	return nil
+}
+
+// shareGrid returns a summary of the game that doesn't reveal the guesses. The index identifies the
+// hidden word: either its index in the answers, or the daily puzzle's number.
+func (game *terminalGame) shareGrid(index int, won bool) string {
+	var sb strings.Builder
+	numGuesses := "X"
+	if won {
+		numGuesses = fmt.Sprint(len(game.history))
+	}
+	fmt.Fprintf(&sb, "gws %v %s/%v", index, numGuesses, PlayMaxGuesses)
+	if PlayHardMode {
+		sb.WriteRune('*')
+	}
+	for _, result := range game.history {
+		sb.WriteRune('\n')
+		for _, lr := range result.Results {
+			switch lr {
+			case gws.LetterResultCorrect:
+				sb.WriteString("🟩")
+			case gws.LetterResultPresentNotHere:
+				sb.WriteString("🟨")
+			default:
+				sb.WriteString("⬛")
+			}
+		}
+	}
+	return sb.String()
+}
+
+// The rows of the on-screen keyboard.
+var keyboardRows = []string{"qwertyuiop", "asdfghjkl", "zxcvbnm"}
+
+// formatKeyboard returns the on-screen keyboard, with each letter coloured by what is known about
+// it so far.
+func (game *terminalGame) formatKeyboard() string {
+	var sb strings.Builder
+	for i, row := range keyboardRows {
+		sb.WriteString(strings.Repeat(" ", i))
+		for _, letter := range row {
+			sb.WriteString(colorLetter(letter, game.keyboardState(letter)))
+		}
+		sb.WriteRune('\n')
+	}
+	return sb.String()
+}
+
+// keyboardState returns the best known result for the given letter, in any location.
+func (game *terminalGame) keyboardState(letter rune) gws.LetterResult {
+	state := gws.LetterResultUnknown
+	for i := 0; i < game.objective.Len(); i++ {
+		switch game.restrictions.State(letter, uint8(i)) {
+		case gws.LetterRestrictionHere:
+			return gws.LetterResultCorrect
+		case gws.LetterRestrictionPresentMaybeHere, gws.LetterRestrictionPresentNotHere:
+			state = gws.LetterResultPresentNotHere
+		case gws.LetterRestrictionNotPresent:
+			return gws.LetterResultNotPresent
+		}
+	}
+	return state
+}
+
+// formatResult returns the guess with each letter coloured by its result.
+func formatResult(result *gws.GuessResult) string {
+	var sb strings.Builder
+	for i, lr := range result.Results {
+		sb.WriteString(colorLetter(result.Guess.At(i), lr))
+	}
+	return sb.String()
+}
+
+// ANSI escape codes for colouring letters by result.
+const (
+	ansiReset      = "\x1b[0m"
+	ansiCorrect    = "\x1b[1;30;42m"
+	ansiPresent    = "\x1b[1;30;43m"
+	ansiNotPresent = "\x1b[1;37;100m"
+)
+
+// colorLetter returns the letter in upper case, coloured by the given result.
+//
+// Without colour, correct letters are shown in square brackets, present letters in parentheses,
+// and letters that are not present in lower case.
+func colorLetter(letter rune, lr gws.LetterResult) string {
+	upper := strings.ToUpper(string(letter))
+	text := " " + upper + " "
+	if !PlayColor {
+		switch lr {
+		case gws.LetterResultCorrect:
+			return "[" + upper + "]"
+		case gws.LetterResultPresentNotHere:
+			return "(" + upper + ")"
+		case gws.LetterResultNotPresent:
+			return " " + string(letter) + " "
+		default:
+			return text
+		}
+	}
+	switch lr {
+	case gws.LetterResultCorrect:
+		return ansiCorrect + text + ansiReset
+	case gws.LetterResultPresentNotHere:
+		return ansiPresent + text + ansiReset
+	case gws.LetterResultNotPresent:
+		return ansiNotPresent + text + ansiReset
+	default:
+		return text
+	}
+}
@@ -2,6 +2,7 @@ package cmd
 
 import (
 	"fmt"
+	"math/rand"
 	"os"
 	"time"
 
@@ -11,10 +12,23 @@ import (
 
 var WordBankPath string
 var Guesser string
+var WordLength uint8
+var Locale string
+var FoldAccents bool
+var KeepLetters string
 
-var validGuessers [2]string = [2]string{"random", "max_eliminations"}
+var NumLies int
+var Rule string
+var EndgameThreshold int
+var NumThreads int
+var Scorers string
+var Normalization string
 
-var wordBank gws.WordBank
+var validGuessers [8]string = [8]string{"random", "max_eliminations", "located_letters", "letter_frequency", "weighted", "win_probability", "fibble", "xordle"}
+
+var normalizer gws.Normalizer
+var wordBanks gws.MultiLengthWordBank
+var wordBank *gws.WordBank
 var guesser gws.Guesser
 
 var rootCmd = &cobra.Command{
@@ -26,8 +40,18 @@ once.`,
 }
 
 func Execute() {
-	rootCmd.PersistentFlags().StringVarP(&WordBankPath, "word_bank", "w", "../data/improved-words.txt", "Path to a list of words to use as the word bank.")
+	rootCmd.PersistentFlags().StringVarP(&WordBankPath, "word_bank", "w", "improved", fmt.Sprintf("The name of a built-in word list %v, or the path to a list of words to use as the word bank.", gws.BuiltinWordListNames()))
 	rootCmd.PersistentFlags().StringVarP(&Guesser, "guesser", "g", "max_eliminations", fmt.Sprintf("The guessing algorithm to use. Options: %s.", validGuessers))
+	rootCmd.PersistentFlags().IntVar(&NumLies, "lies", 1, "The number of letters that are lies in each result when using the fibble guesser. When benchmarking or solving, this many lies are added to each result.")
+	rootCmd.PersistentFlags().IntVar(&EndgameThreshold, "endgame_threshold", gws.DefaultEndgameThreshold, "The number of possible words at or below which the guesser searches exactly for the best guess. Applies to the max_eliminations, located_letters, letter_frequency, and weighted guessers. If 0, the search is disabled.")
+	rootCmd.PersistentFlags().IntVar(&NumThreads, "threads", 1, "The number of threads that score the candidate guesses for each turn. Applies to the max_eliminations, located_letters, letter_frequency, and weighted guessers. Benchmarks already play games in parallel, so this is most useful when solving or playing.")
+	rootCmd.PersistentFlags().StringVar(&Scorers, "scorers", "eliminations:1,random:0.1", fmt.Sprintf("The scorers and their weights for the weighted guesser, e.g. \"eliminations:1,random:0.1\". Options: %v.", gws.ScorerNames()))
+	rootCmd.PersistentFlags().StringVar(&Normalization, "normalization", "rank", "How the weighted guesser normalizes each scorer's scores before weighting them. Options: [rank zscore].")
+	rootCmd.PersistentFlags().StringVar(&Rule, "rule", "wordle", fmt.Sprintf("The feedback rule to use when solving or benchmarking. Options: %v.", gws.FeedbackRuleNames()))
+	rootCmd.PersistentFlags().Uint8VarP(&WordLength, "length", "l", 0, "The length of words to play with. Required if the word bank contains words of multiple lengths.")
+	rootCmd.PersistentFlags().StringVar(&Locale, "locale", "", "The locale to use when converting words to lower case, e.g. \"tr\" for Turkish.")
+	rootCmd.PersistentFlags().BoolVar(&FoldAccents, "fold_accents", false, "Whether to remove accents from letters, e.g. so that \"é\" matches \"e\".")
+	rootCmd.PersistentFlags().StringVar(&KeepLetters, "keep_letters", "", "Letters that keep their accents when using --fold_accents, e.g. \"ñ\" for Spanish.")
 
 	start := time.Now()
 	if err := rootCmd.Execute(); err != nil {
@@ -40,41 +64,166 @@ func Execute() {
 }
 
 func initRoot() {
-	err := initWordBank()
+	err := initWordBanks()
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
-	err = initGuesser()
+	err = selectWordLength(WordLength)
 	if err != nil {
 		fmt.Fprintln(os.Stderr, err)
 		os.Exit(1)
 	}
 }
 
-func initWordBank() error {
-	f, err := os.Open(WordBankPath)
+func initNormalizer() (gws.Normalizer, error) {
+	return gws.InitNormalizer(gws.NormalizerOptions{
+		Locale:      Locale,
+		FoldAccents: FoldAccents,
+		KeepLetters: KeepLetters,
+	})
+}
+
+func initWordBanks() error {
+	var err error
+	normalizer, err = initNormalizer()
 	if err != nil {
 		return err
 	}
-	wordBank, err = gws.WordBankFromReader(f)
+	f, err := openWordList(WordBankPath)
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+	wordBanks, err = gws.MultiLengthWordBankFromReaderWithNormalizer(f, normalizer)
 	return err
 }
 
+// selectWordLength sets the word bank and guesser to play games with words of the given length,
+// using the feedback rule from --rule.
+//
+// If length is zero, the word bank must only contain words of a single length.
+func selectWordLength(length uint8) error {
+	if err := selectWordBank(length); err != nil {
+		return err
+	}
+	rule, err := gws.FeedbackRuleFromName(Rule)
+	if err != nil {
+		return err
+	}
+	bank := wordBank.WithFeedbackRule(rule)
+	wordBank = &bank
+	return initGuesser()
+}
+
+// selectWordBank sets the word bank to play games with words of the given length.
+//
+// If length is zero, the word bank must only contain words of a single length.
+func selectWordBank(length uint8) error {
+	var err error
+	wordBank, err = selectLength(&wordBanks, length)
+	return err
+}
+
+// selectLength selects the bank of words with the given length.
+//
+// If length is zero, the banks must only contain words of a single length.
+func selectLength(banks *gws.MultiLengthWordBank, length uint8) (*gws.WordBank, error) {
+	if length == 0 {
+		lengths := banks.WordLengths()
+		if len(lengths) > 1 {
+			return nil, fmt.Errorf("The word bank contains words of multiple lengths (%v). Select one with --length.", lengths)
+		}
+		length = lengths[0]
+	}
+	return banks.ForLength(length)
+}
+
 func initGuesser() error {
+	if EndgameThreshold < 0 {
+		return fmt.Errorf("The endgame threshold can't be negative. Got %v.", EndgameThreshold)
+	}
+	if NumThreads < 1 {
+		return fmt.Errorf("The number of threads must be at least 1. Got %v.", NumThreads)
+	}
+	if (Guesser == "fibble" || Guesser == "xordle") && Rule != "wordle" {
+		return fmt.Errorf("The %s guesser only supports the wordle rule. Got %s.", Guesser, Rule)
+	}
 	switch Guesser {
 	case "random":
-		g := gws.InitRandomGuesser(&wordBank)
+		g := gws.InitRandomGuesser(wordBank)
 		guesser = &g
 	case "max_eliminations":
-		scorer, err := gws.InitMaxEliminationsScorer(&wordBank)
+		scorer, err := gws.InitMaxEliminationsScorer(wordBank)
+		if err != nil {
+			return err
+		}
+		guesser = initMaxScoreGuesser(&scorer)
+	case "located_letters":
+		scorer := gws.InitLocatedLettersScorer(wordBank)
+		guesser = initMaxScoreGuesser(&scorer)
+	case "letter_frequency":
+		scorer := gws.InitLetterFrequencyScorer(wordBank)
+		guesser = initMaxScoreGuesser(&scorer)
+	case "weighted":
+		normalization, err := gws.ScoreNormalizationFromName(Normalization)
+		if err != nil {
+			return err
+		}
+		scorer, err := gws.ParseWeightedScorer(wordBank, Scorers, normalization)
+		if err != nil {
+			return err
+		}
+		guesser = initMaxScoreGuesser(&scorer)
+	case "win_probability":
+		scorer, err := gws.InitMaxEliminationsScorer(wordBank)
+		if err != nil {
+			return err
+		}
+		g := gws.InitWinProbabilityGuesser(wordBank, &scorer, gws.GuessModeAll)
+		guesser = &g
+	case "fibble":
+		g, err := gws.InitFibbleGuesser(wordBank, NumLies, gws.GuessModeAll)
+		if err != nil {
+			return err
+		}
+		guesser = &g
+	case "xordle":
+		scorer, err := gws.InitMaxEliminationsScorer(wordBank)
+		if err != nil {
+			return err
+		}
+		g, err := gws.InitXordleGuesser(wordBank, &scorer, gws.GuessModeAll)
 		if err != nil {
 			return err
 		}
-		g := gws.InitMaxScoreGuesser(&wordBank, &scorer, gws.GuessModeAll)
 		guesser = &g
 	default:
 		return fmt.Errorf("Did not recognize guesser type %s. Accepted options: %s", Guesser, validGuessers)
 	}
 	return nil
 }
+
+// initMaxScoreGuesser constructs a max score guesser with the given scorer, configured from the
+// flags.
+func initMaxScoreGuesser[S gws.WordScorer](scorer S) gws.Guesser {
+	g := gws.InitMaxScoreGuesser(wordBank, scorer, gws.GuessModeAll)
+	g.SetEndgameThreshold(EndgameThreshold)
+	g.SetNumThreads(NumThreads)
+	return &g
+}
+
+// playGame plays a game with the given guesser. When using the fibble guesser, lies are added to
+// each result. When using the xordle guesser, there must be two objectives.
+func playGame(objectives []gws.Word, maxNumGuesses int, guesser gws.Guesser, rng *rand.Rand) (gws.GameResult, error) {
+	switch Guesser {
+	case "fibble":
+		return gws.PlayFibbleGameWithGuesser(objectives[0], maxNumGuesses, NumLies, rng, guesser)
+	case "xordle":
+		if len(objectives) != 2 {
+			return gws.GameResult{}, fmt.Errorf("Xordle games need two objectives. Got %v.", len(objectives))
+		}
+		return gws.PlayXordleGameWithGuesser(objectives[0], objectives[1], maxNumGuesses, guesser)
+	}
+	return gws.PlayGameWithGuesser(objectives[0], maxNumGuesses, guesser)
+}
@@ -0,0 +1,78 @@
+package cmd
+
+import (
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/MorganR/go-wordle-solver/bin/server"
+	"github.com/spf13/cobra"
+)
+
+var ServeAddr string
+var SessionBanks []string
+var MaxSessions int
+var SessionTimeout time.Duration
+
+func init() {
+	serveCmd.Flags().StringVar(&ServeAddr, "addr", ":8080", "The address to listen on.")
+	serveCmd.Flags().StringSliceVar(&SessionBanks, "session_banks", nil, "Additional built-in word lists or paths to make available to sessions, by name or path.")
+	serveCmd.Flags().IntVar(&MaxSessions, "max_sessions", server.DefaultSessionOptions.MaxSessions, "The maximum number of live sessions. If 0, there is no limit.")
+	serveCmd.Flags().DurationVar(&SessionTimeout, "session_timeout", server.DefaultSessionOptions.IdleTimeout, "How long a session may be inactive before it expires. If 0, sessions never expire.")
+	rootCmd.AddCommand(serveCmd)
+}
+
+var serveCmd = &cobra.Command{
+	Use:   "serve",
+	Short: "Serves the solver over an HTTP JSON API.",
+	Long: `Serves the solver over an HTTP JSON API.
+
+The stateless endpoints accept POST requests with a JSON body:
+
+  /suggest  {"history": [{"guess": "arise", "result": "-y--g"}]}
+  /filter   {"history": [...], "limit": 10}
+  /score    {"word": "tonal", "history": [...]}
+  /play     {"objective": "tonal", "max_guesses": 6}
+
+Game sessions keep their history on the server:
+
+  POST   /sessions               {"word_bank": "default", "guesser": "max_eliminations"}
+  GET    /sessions/{id}
+  POST   /sessions/{id}/guesses  {"guess": "arise", "result": "-y--g"}
+  POST   /sessions/{id}/undo
+  DELETE /sessions/{id}
+
+Results use 'g' for correct letters, 'y' for present letters, and '-' for letters that are not
+present. The --word_bank is available to sessions as "default", and each of the --session_banks is
+available by the name or path it was given as.`,
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		if err := initWordBanks(); err != nil {
+			return err
+		}
+		if err := selectWordBank(WordLength); err != nil {
+			return err
+		}
+		s, err := server.InitServer(wordBank)
+		if err != nil {
+			return err
+		}
+		defer s.Close()
+		for _, nameOrPath := range SessionBanks {
+			bank, err := loadWordBank(nameOrPath, wordBank.WordLength())
+			if err != nil {
+				return err
+			}
+			if err := s.AddWordBank(nameOrPath, bank); err != nil {
+				return err
+			}
+		}
+		options := server.DefaultSessionOptions
+		options.MaxSessions = MaxSessions
+		options.IdleTimeout = SessionTimeout
+		s.SetSessionOptions(options)
+
+		fmt.Printf("Listening on %s\n", ServeAddr)
+		return http.ListenAndServe(ServeAddr, s.Handler())
+	},
+}
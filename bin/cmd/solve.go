@@ -1,47 +1,85 @@
 package cmd
 
 import (
+	"errors"
 	"fmt"
+	"math/rand"
 	"os"
 	"time"
 
 	gws "github.com/MorganR/go-wordle-solver/lib"
 	"github.com/spf13/cobra"
+	"golang.org/x/exp/slices"
 )
 
+var SolveDate string
+
 func init() {
+	solveCmd.Flags().StringVar(&SolveDate, "date", "", "Solve the daily puzzle for this date (YYYY-MM-DD) instead of a given word.")
+	addDailyFlags(solveCmd)
 	rootCmd.AddCommand(solveCmd)
 }
 
 var solveCmd = &cobra.Command{
-	Use:   "solve",
+	Use:   "solve [objective] [second objective]",
 	Short: "Solves a single Wordle puzzle.",
-	Args:  cobra.ExactArgs(1),
-	Run: func(cmd *cobra.Command, args []string) {
-		initRoot()
-		objective := gws.WordFromString(args[0])
+	Long: `Solves a single Wordle puzzle.
 
-		if objective.Len() != int(wordBank.WordLength()) {
-			fmt.Fprintf(os.Stderr, "The objective word's length (%v) must match the word bank (%v).\n", objective.Len(), wordBank.WordLength())
+The objective is either given as an argument, or is the daily puzzle for the given --date. The
+xordle guesser needs two objectives with no letters in common, given as arguments.`,
+	Args: cobra.RangeArgs(0, 2),
+	Run: func(cmd *cobra.Command, args []string) {
+		if err := initWordBanks(); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+			return
+		}
+		objectives, err := solveObjectives(args)
+		if err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+			return
+		}
+		length := WordLength
+		if length == 0 {
+			// Play with words that are the same length as the objective.
+			length = uint8(objectives[0].Len())
+		}
+		if err := selectWordLength(length); err != nil {
+			fmt.Fprintln(os.Stderr, err)
 			os.Exit(1)
 			return
 		}
+
 		pw := wordBank.Words()
-		hasWord := false
-		for i := 0; i < pw.Len(); i++ {
-			if pw.At(i).Equal(objective) {
-				hasWord = true
-				break
+		for _, objective := range objectives {
+			if objective.Len() != int(wordBank.WordLength()) {
+				fmt.Fprintf(os.Stderr, "The objective word's length (%v) must match the word bank (%v).\n", objective.Len(), wordBank.WordLength())
+				os.Exit(1)
+				return
+			}
+			hasWord := false
+			for i := 0; i < pw.Len(); i++ {
+				if pw.At(i).Equal(objective) {
+					hasWord = true
+					break
+				}
+			}
+			if !hasWord {
+				fmt.Fprintf(os.Stderr, "The objective word (%s) is not present in the word bank. Try another.\n", objective)
+				os.Exit(1)
+				return
 			}
 		}
-		if !hasWord {
-			fmt.Fprintf(os.Stderr, "The objective word (%s) is not present in the word bank. Try another.\n", objective)
+		if len(objectives) == 2 && !gws.IsValidXordlePair(objectives[0], objectives[1]) {
+			fmt.Fprintf(os.Stderr, "The objective words (%s and %s) must have no letters in common.\n", objectives[0], objectives[1])
 			os.Exit(1)
 			return
 		}
 
+		recorder := &feedbackRecorder{Guesser: guesser}
 		start := time.Now()
-		result, err := gws.PlayGameWithGuesser(objective, 128, guesser)
+		result, err := playGame(objectives, 128, recorder, rand.New(rand.NewSource(time.Now().UnixNano())))
 		if err != nil {
 			fmt.Fprintf(os.Stderr, "Internal error: %s\n", err)
 			os.Exit(1)
@@ -52,11 +90,125 @@ var solveCmd = &cobra.Command{
 		switch result.Status {
 		case gws.GameSuccess:
 			fmt.Printf("Solved! It took me %v guesses.\n", len(result.Turns))
-			printGuesses(result.Turns)
+			printGuessesWithFeedback(objectives, result.Turns, recorder.results)
 		case gws.GameFailure:
 			fmt.Println("Failed :( I couldn't guess the word within the guess limit.")
-			printGuesses(result.Turns)
+			printGuessesWithFeedback(objectives, result.Turns, recorder.results)
 		}
 		fmt.Printf("Guessing took %s.\n", elapsed)
 	},
 }
+
+// solveObjectives returns the objectives to solve from the arguments, or from the --date. The
+// xordle guesser needs two objectives, and other guessers need one.
+func solveObjectives(args []string) ([]gws.Word, error) {
+	if Guesser == "xordle" {
+		if SolveDate != "" || len(args) != 2 {
+			return nil, errors.New("The xordle guesser needs two objective words, and can't be used with --date.")
+		}
+	} else if (len(args) == 1) == (SolveDate != "") || len(args) > 1 {
+		return nil, errors.New("Provide either an objective word or a --date, but not both.")
+	}
+	if SolveDate != "" {
+		objective, err := dailyObjective(SolveDate)
+		if err != nil {
+			return nil, err
+		}
+		return []gws.Word{objective}, nil
+	}
+	objectives := make([]gws.Word, len(args))
+	for i, arg := range args {
+		objectives[i] = normalizer.WordFromString(arg)
+	}
+	return objectives, nil
+}
+
+// printGuessesWithFeedback prints each guess, followed by a summary of what is known about the objective when
+// using the wordle rule, or by the feedback for the guess otherwise.
+//
+// The feedback is the results the guesser was given, which include any lies when using the fibble
+// guesser, and combine both objectives when using the xordle guesser. The last guess's feedback is
+// never given to the guesser, so its true feedback is shown.
+func printGuessesWithFeedback(objectives []gws.Word, turns []gws.TurnData, given []gws.GuessResult) {
+	if _, isWordle := wordBank.FeedbackRule().(gws.WordleRule); isWordle && Guesser != "fibble" && Guesser != "xordle" {
+		printGuessesWithSummaries(objectives[0], turns)
+		return
+	}
+	rule := wordBank.FeedbackRule()
+	for i, td := range turns {
+		fmt.Printf("\t%v: %s (%v remaining)\n", i+1, td.Guess, td.NumPossibleWordsBeforeGuess)
+		if i < len(given) {
+			fmt.Printf("\t   %s\n", gws.LetterResultsString(given[i].Results))
+			continue
+		}
+		var result gws.GuessResult
+		var err error
+		if len(objectives) == 2 {
+			result, err = gws.GetXordleResultForGuess(objectives[0], objectives[1], td.Guess)
+		} else {
+			result, err = rule.Feedback(objectives[0], td.Guess)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Internal error: %s\n", err)
+			return
+		}
+		fmt.Printf("\t   %s\n", gws.LetterResultsString(result.Results))
+	}
+}
+
+// feedbackRecorder wraps a guesser to record the results it's given, which may differ from the
+// true results, e.g. when lies are added for the fibble guesser.
+type feedbackRecorder struct {
+	gws.Guesser
+	results []gws.GuessResult
+}
+
+func (r *feedbackRecorder) Reset() {
+	r.Guesser.Reset()
+	r.results = nil
+}
+
+func (r *feedbackRecorder) Update(result *gws.GuessResult) error {
+	if err := r.Guesser.Update(result); err != nil {
+		return err
+	}
+	r.results = append(r.results, gws.GuessResult{Guess: result.Guess, Results: slices.Clone(result.Results)})
+	return nil
+}
+
+// SetRemainingGuesses passes the number of remaining guesses on to the wrapped guesser, if it's a
+// [gws.BudgetAwareGuesser].
+func (r *feedbackRecorder) SetRemainingGuesses(numGuesses int) {
+	if budgetAware, isBudgetAware := r.Guesser.(gws.BudgetAwareGuesser); isBudgetAware {
+		budgetAware.SetRemainingGuesses(numGuesses)
+	}
+}
+
+// printGuessesWithSummaries prints each guess, followed by a summary of what is known about the
+// objective after that guess.
+func printGuessesWithSummaries(objective gws.Word, turns []gws.TurnData) {
+	restrictions := gws.InitWordRestrictions(uint8(objective.Len()))
+	for i, td := range turns {
+		fmt.Printf("\t%v: %s (%v remaining)\n", i+1, td.Guess, td.NumPossibleWordsBeforeGuess)
+		result, err := gws.GetResultForGuess(objective, td.Guess)
+		if err == nil {
+			err = restrictions.Update(&result)
+		}
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Internal error: %s\n", err)
+			return
+		}
+		summary := restrictions.Summary()
+		fmt.Printf("\t   %s\n", &summary)
+	}
+}
+
+// dailyObjective returns the objective for the daily puzzle on the given date.
+func dailyObjective(dateStr string) (gws.Word, error) {
+	number, objective, err := dailyPuzzle(dateStr, WordLength)
+	if err != nil {
+		return gws.Word{}, err
+	}
+	fmt.Printf("Puzzle %v (%s): %s\n", number, dateStr, objective)
+	return objective, nil
+}
@@ -2,10 +2,42 @@ package cmd
 
 import (
 	"fmt"
+	"io"
+	"os"
 
 	gws "github.com/MorganR/go-wordle-solver/lib"
 )
 
+// openWordList opens the built-in word list with the given name, or otherwise the file at the
+// given path.
+func openWordList(nameOrPath string) (io.ReadCloser, error) {
+	if gws.IsBuiltinWordList(nameOrPath) {
+		r, err := gws.OpenBuiltinWordList(nameOrPath)
+		if err != nil {
+			return nil, err
+		}
+		return io.NopCloser(r), nil
+	}
+	return os.Open(nameOrPath)
+}
+
+// loadWordBank loads the word list with the given name or path, and selects the words with the
+// given length. The words are normalized with the configured normalizer.
+//
+// If length is zero, the list must only contain words of a single length.
+func loadWordBank(nameOrPath string, length uint8) (*gws.WordBank, error) {
+	f, err := openWordList(nameOrPath)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	banks, err := gws.MultiLengthWordBankFromReaderWithNormalizer(f, normalizer)
+	if err != nil {
+		return nil, err
+	}
+	return selectLength(&banks, length)
+}
+
 func printGuesses(turns []gws.TurnData) {
 	for i, td := range turns {
 		fmt.Printf("\t%v: %s (%v remaining)\n", i+1, td.Guess, td.NumPossibleWordsBeforeGuess)
@@ -0,0 +1,407 @@
+// Package server provides an HTTP JSON API for solving Wordle puzzles.
+package server
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	gws "github.com/MorganR/go-wordle-solver/lib"
+)
+
+// The maximum size of a request body.
+const maxRequestBytes int64 = 1 << 20
+
+// The maximum number of guesses allowed when playing a game, if not specified.
+const defaultMaxGuesses int = 128
+
+// Server serves the solver over an HTTP JSON API.
+//
+// The stateless endpoints accept POST requests with a JSON body:
+//
+//   - /suggest: Suggests the next guess for a guess history.
+//   - /filter: Lists the possible words remaining after a guess history.
+//   - /score: Scores a word given a guess history.
+//   - /play: Plays a full game for a given objective.
+//
+// Guess histories are provided as a list of guesses and their results, where each result is a
+// compact string as parsed by [gws.LetterResultsFromString], e.g. "gy--g".
+//
+// Each request uses its own copy of a single, precomputed [gws.MaxEliminationsScorer], so requests
+// may be served concurrently.
+//
+// The server also supports stateful game sessions. See [Server.SessionHandler] for details.
+type Server struct {
+	bank   *gws.WordBank
+	scorer gws.MaxEliminationsScorer
+
+	// The word banks available for sessions, by name.
+	banksMu sync.RWMutex
+	banks   map[string]*sessionBank
+
+	sessions sessionStore
+}
+
+// DefaultWordBankName is the name of the word bank provided to [InitServer], for use in sessions.
+const DefaultWordBankName string = "default"
+
+// InitServer constructs a [Server] for the given bank. **Be careful, this is expensive to
+// compute!** See [gws.InitMaxEliminationsScorer].
+//
+// The bank is also available to sessions as [DefaultWordBankName].
+func InitServer(bank *gws.WordBank) (*Server, error) {
+	scorer, err := gws.InitMaxEliminationsScorer(bank)
+	if err != nil {
+		return nil, err
+	}
+	s := &Server{
+		bank:     bank,
+		scorer:   scorer,
+		banks:    make(map[string]*sessionBank),
+		sessions: initSessionStore(DefaultSessionOptions, time.Now),
+	}
+	s.banks[DefaultWordBankName] = initSessionBank(bank, scorer)
+	s.sessions.startReaper(sessionReapInterval)
+	return s, nil
+}
+
+// Close stops the server's background work, such as removing expired sessions. The server's
+// handlers may still be used, but expired sessions are then only removed when sessions are created
+// or used.
+func (s *Server) Close() {
+	s.sessions.stop()
+}
+
+// Handler provides an [http.Handler] that serves this server's API.
+func (s *Server) Handler() http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/suggest", handleJSON(s.suggest))
+	mux.HandleFunc("/filter", handleJSON(s.filter))
+	mux.HandleFunc("/score", handleJSON(s.score))
+	mux.HandleFunc("/play", handleJSON(s.play))
+	sessions := s.SessionHandler()
+	mux.Handle("/sessions", sessions)
+	mux.Handle("/sessions/", sessions)
+	return mux
+}
+
+// Guess is a single guess and its result.
+type Guess struct {
+	// The word that was guessed.
+	Guess string `json:"guess"`
+	// The result of the guess, e.g. "gy--g".
+	Result string `json:"result"`
+}
+
+// SuggestRequest is the request body for /suggest.
+type SuggestRequest struct {
+	History []Guess `json:"history"`
+}
+
+// SuggestResponse is the response body for /suggest.
+type SuggestResponse struct {
+	// The suggested guess.
+	Suggestion string `json:"suggestion"`
+	// The number of words that are still possible.
+	NumPossibleWords int `json:"num_possible_words"`
+}
+
+// FilterRequest is the request body for /filter.
+type FilterRequest struct {
+	History []Guess `json:"history"`
+	// The maximum number of words to return. If zero, all words are returned.
+	Limit int `json:"limit"`
+}
+
+// FilterResponse is the response body for /filter.
+type FilterResponse struct {
+	// The possible words, up to the requested limit.
+	Words []string `json:"words"`
+	// The total number of possible words.
+	NumPossibleWords int `json:"num_possible_words"`
+}
+
+// ScoreRequest is the request body for /score.
+type ScoreRequest struct {
+	// The word to score.
+	Word    string  `json:"word"`
+	History []Guess `json:"history"`
+}
+
+// ScoreResponse is the response body for /score.
+type ScoreResponse struct {
+	Word string `json:"word"`
+	// The word's score. Higher is better.
+	Score int64 `json:"score"`
+}
+
+// PlayRequest is the request body for /play.
+type PlayRequest struct {
+	// The word to guess. This must be in the server's word bank.
+	Objective string `json:"objective"`
+	// The maximum number of guesses. If zero, a large default is used.
+	MaxGuesses int `json:"max_guesses"`
+}
+
+// Turn describes a single turn of a game.
+type Turn struct {
+	Guess
+	// The number of words that were possible before this guess.
+	NumPossibleWordsBeforeGuess uint `json:"num_possible_words_before_guess"`
+}
+
+// PlayResponse is the response body for /play.
+type PlayResponse struct {
+	// Either "success" or "failure".
+	Status string `json:"status"`
+	Turns  []Turn `json:"turns"`
+}
+
+// ErrorResponse is the response body when a request fails.
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// requestError is an error that should be reported with a specific HTTP status.
+type requestError struct {
+	status int
+	err    error
+}
+
+func (e requestError) Error() string {
+	return e.err.Error()
+}
+
+func badRequestf(format string, args ...any) error {
+	return requestError{http.StatusBadRequest, fmt.Errorf(format, args...)}
+}
+
+func notFoundf(format string, args ...any) error {
+	return requestError{http.StatusNotFound, fmt.Errorf(format, args...)}
+}
+
+func unavailablef(format string, args ...any) error {
+	return requestError{http.StatusServiceUnavailable, fmt.Errorf(format, args...)}
+}
+
+// handleJSON adapts a function that handles JSON requests into an [http.HandlerFunc].
+func handleJSON[Req any, Resp any](fn func(*Req) (Resp, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeMethodNotAllowed(w, http.MethodPost)
+			return
+		}
+		var req Req
+		if err := decodeJSON(w, r, &req); err != nil {
+			writeError(w, err)
+			return
+		}
+		resp, err := fn(&req)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusOK, resp)
+	}
+}
+
+// decodeJSON decodes the request body into req. Returns a bad request error if this fails.
+func decodeJSON(w http.ResponseWriter, r *http.Request, req any) error {
+	decoder := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxRequestBytes))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(req); err != nil {
+		return badRequestf("Invalid request body: %s", err)
+	}
+	return nil
+}
+
+// writeError writes the given error as an [ErrorResponse].
+//
+// [requestError]s use their own status. Any other errors result in a 500 response.
+func writeError(w http.ResponseWriter, err error) {
+	status := http.StatusInternalServerError
+	var reqErr requestError
+	if errors.As(err, &reqErr) {
+		status = reqErr.status
+	}
+	writeJSON(w, status, ErrorResponse{err.Error()})
+}
+
+func writeMethodNotAllowed(w http.ResponseWriter, allowed ...string) {
+	w.Header().Set("Allow", strings.Join(allowed, ", "))
+	writeJSON(w, http.StatusMethodNotAllowed, ErrorResponse{fmt.Sprintf("Only %s requests are supported.", strings.Join(allowed, " and "))})
+}
+
+func writeJSON(w http.ResponseWriter, status int, body any) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(body)
+}
+
+// newGuesser constructs a new guesser with its own copy of the scorer, updated with the given
+// history.
+func (s *Server) newGuesser(history []Guess) (*gws.MaxScoreGuesser[gws.WordScorer], gws.WordScorer, error) {
+	scorer := s.scorer.Copy()
+	guesser := gws.InitMaxScoreGuesser(s.bank, scorer, gws.GuessModeAll)
+	guesser.Reset()
+	results, err := parseHistory(s.bank, history)
+	if err != nil {
+		return nil, nil, err
+	}
+	for i := range results {
+		if err := guesser.Update(&results[i]); err != nil {
+			return nil, nil, badRequestf("%s", err)
+		}
+	}
+	return &guesser, scorer, nil
+}
+
+func parseHistory(bank *gws.WordBank, history []Guess) ([]gws.GuessResult, error) {
+	results := make([]gws.GuessResult, len(history))
+	for i, guess := range history {
+		var err error
+		results[i], err = parseGuess(bank, guess)
+		if err != nil {
+			return nil, badRequestf("Invalid guess %v: %s", i+1, err)
+		}
+	}
+	return results, nil
+}
+
+func parseGuess(bank *gws.WordBank, guess Guess) (gws.GuessResult, error) {
+	word, err := parseWord(bank, guess.Guess)
+	if err != nil {
+		return gws.GuessResult{}, err
+	}
+	letterResults, err := gws.LetterResultsFromString(guess.Result)
+	if err != nil {
+		return gws.GuessResult{}, err
+	}
+	if len(letterResults) != word.Len() {
+		return gws.GuessResult{}, fmt.Errorf("the result (%s) must be the same length as the guess (%s).", guess.Result, word)
+	}
+	return gws.GuessResult{Guess: word, Results: letterResults}, nil
+}
+
+func parseWord(bank *gws.WordBank, str string) (gws.Word, error) {
+	word := bank.Normalizer().WordFromString(str)
+	if word.Len() != int(bank.WordLength()) {
+		return gws.Word{}, fmt.Errorf("the word (%s) must have length %v.", word, bank.WordLength())
+	}
+	return word, nil
+}
+
+func (s *Server) inBank(word gws.Word) bool {
+	words := s.bank.Words()
+	for i := 0; i < words.Len(); i++ {
+		if words.At(i).Equal(word) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s *Server) suggest(req *SuggestRequest) (SuggestResponse, error) {
+	guesser, _, err := s.newGuesser(req.History)
+	if err != nil {
+		return SuggestResponse{}, err
+	}
+	maybeGuess := guesser.SelectNextGuess()
+	if !maybeGuess.HasValue() {
+		return SuggestResponse{}, badRequestf("No words in the word bank match this history.")
+	}
+	return SuggestResponse{
+		Suggestion:       maybeGuess.Value().String(),
+		NumPossibleWords: guesser.PossibleWords().Len(),
+	}, nil
+}
+
+func (s *Server) filter(req *FilterRequest) (FilterResponse, error) {
+	if req.Limit < 0 {
+		return FilterResponse{}, badRequestf("The limit must not be negative.")
+	}
+	results, err := parseHistory(s.bank, req.History)
+	if err != nil {
+		return FilterResponse{}, err
+	}
+	pw := s.bank.Words()
+	for i := range results {
+		if err := pw.Filter(&results[i]); err != nil {
+			return FilterResponse{}, badRequestf("%s", err)
+		}
+	}
+	numWords := pw.Len()
+	if req.Limit > 0 && req.Limit < numWords {
+		numWords = req.Limit
+	}
+	words := make([]string, numWords)
+	for i := range words {
+		words[i] = pw.At(i).String()
+	}
+	return FilterResponse{
+		Words:            words,
+		NumPossibleWords: pw.Len(),
+	}, nil
+}
+
+func (s *Server) score(req *ScoreRequest) (ScoreResponse, error) {
+	word, err := parseWord(s.bank, req.Word)
+	if err != nil {
+		return ScoreResponse{}, badRequestf("Invalid word: %s", err)
+	}
+	_, scorer, err := s.newGuesser(req.History)
+	if err != nil {
+		return ScoreResponse{}, err
+	}
+	return ScoreResponse{
+		Word:  word.String(),
+		Score: scorer.ScoreWord(word),
+	}, nil
+}
+
+func (s *Server) play(req *PlayRequest) (PlayResponse, error) {
+	objective, err := parseWord(s.bank, req.Objective)
+	if err != nil {
+		return PlayResponse{}, badRequestf("Invalid objective: %s", err)
+	}
+	if !s.inBank(objective) {
+		return PlayResponse{}, badRequestf("The objective (%s) is not in the word bank.", objective)
+	}
+	if req.MaxGuesses < 0 {
+		return PlayResponse{}, badRequestf("The maximum number of guesses must not be negative.")
+	}
+	maxGuesses := req.MaxGuesses
+	if maxGuesses == 0 {
+		maxGuesses = defaultMaxGuesses
+	}
+	guesser, _, err := s.newGuesser(nil)
+	if err != nil {
+		return PlayResponse{}, err
+	}
+	result, err := gws.PlayGameWithGuesser(objective, maxGuesses, guesser)
+	if err != nil {
+		return PlayResponse{}, err
+	}
+	turns := make([]Turn, len(result.Turns))
+	for i, turn := range result.Turns {
+		guessResult, err := gws.GetResultForGuess(objective, turn.Guess)
+		if err != nil {
+			return PlayResponse{}, err
+		}
+		turns[i] = Turn{
+			Guess: Guess{
+				Guess:  turn.Guess.String(),
+				Result: gws.LetterResultsString(guessResult.Results),
+			},
+			NumPossibleWordsBeforeGuess: turn.NumPossibleWordsBeforeGuess,
+		}
+	}
+	return PlayResponse{
+		Status: result.Status.String(),
+		Turns:  turns,
+	}, nil
+}
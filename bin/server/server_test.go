@@ -0,0 +1,176 @@
+package server
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	gws "github.com/MorganR/go-wordle-solver/lib"
+	"gotest.tools/v3/assert"
+)
+
+func initTestServer(t *testing.T) *httptest.Server {
+	t.Helper()
+	_, ts := initTestServerAndHandler(t)
+	return ts
+}
+
+func initTestServerAndHandler(t *testing.T) (*Server, *httptest.Server) {
+	t.Helper()
+	bank, err := gws.WordBankFromSlice([]string{"abcd", "bcde", "cdef", "defg", "efgh", "fghi", "ghij"})
+	assert.NilError(t, err)
+	s, err := InitServer(&bank)
+	assert.NilError(t, err)
+	t.Cleanup(s.Close)
+	ts := httptest.NewServer(s.Handler())
+	t.Cleanup(ts.Close)
+	return s, ts
+}
+
+func post(t *testing.T, ts *httptest.Server, path string, body any, resp any) int {
+	t.Helper()
+	return doRequest(t, ts, http.MethodPost, path, body, resp)
+}
+
+// doRequest sends a request with the given body encoded as JSON, if not nil, and decodes the
+// response into resp, if not nil.
+func doRequest(t *testing.T, ts *httptest.Server, method, path string, body any, resp any) int {
+	t.Helper()
+	status, err := sendRequest(ts, method, path, body, resp)
+	assert.NilError(t, err)
+	return status
+}
+
+// sendRequest is like [doRequest], but returns an error instead of failing the test, so that it can
+// be used from other goroutines.
+func sendRequest(ts *httptest.Server, method, path string, body any, resp any) (int, error) {
+	var reqBody []byte
+	if body != nil {
+		var err error
+		reqBody, err = json.Marshal(body)
+		if err != nil {
+			return 0, err
+		}
+	}
+	req, err := http.NewRequest(method, ts.URL+path, bytes.NewReader(reqBody))
+	if err != nil {
+		return 0, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	httpResp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer httpResp.Body.Close()
+	if resp != nil {
+		if contentType := httpResp.Header.Get("Content-Type"); contentType != "application/json" {
+			return 0, fmt.Errorf("Unexpected content type %q.", contentType)
+		}
+		if err := json.NewDecoder(httpResp.Body).Decode(resp); err != nil {
+			return 0, err
+		}
+	}
+	return httpResp.StatusCode, nil
+}
+
+func TestServerSuggest(t *testing.T) {
+	ts := initTestServer(t)
+
+	var resp SuggestResponse
+	status := post(t, ts, "/suggest", SuggestRequest{}, &resp)
+	assert.Equal(t, status, http.StatusOK)
+	assert.Equal(t, resp.NumPossibleWords, 7)
+	assert.Equal(t, resp.Suggestion, "cdef")
+
+	status = post(t, ts, "/suggest", SuggestRequest{
+		History: []Guess{{Guess: "DEFG", Result: "-yyy"}},
+	}, &resp)
+	assert.Equal(t, status, http.StatusOK)
+	assert.Equal(t, resp.NumPossibleWords, 1)
+	assert.Equal(t, resp.Suggestion, "efgh")
+}
+
+func TestServerFilter(t *testing.T) {
+	ts := initTestServer(t)
+
+	var resp FilterResponse
+	status := post(t, ts, "/filter", FilterRequest{
+		History: []Guess{{Guess: "defg", Result: "y---"}},
+	}, &resp)
+	assert.Equal(t, status, http.StatusOK)
+	assert.DeepEqual(t, resp.Words, []string{"abcd"})
+	assert.Equal(t, resp.NumPossibleWords, 1)
+
+	status = post(t, ts, "/filter", FilterRequest{Limit: 3}, &resp)
+	assert.Equal(t, status, http.StatusOK)
+	assert.DeepEqual(t, resp.Words, []string{"abcd", "bcde", "cdef"})
+	assert.Equal(t, resp.NumPossibleWords, 7)
+}
+
+func TestServerScore(t *testing.T) {
+	ts := initTestServer(t)
+
+	var best ScoreResponse
+	status := post(t, ts, "/score", ScoreRequest{Word: "CDEF"}, &best)
+	assert.Equal(t, status, http.StatusOK)
+	assert.Equal(t, best.Word, "cdef")
+
+	var worse ScoreResponse
+	status = post(t, ts, "/score", ScoreRequest{Word: "abcd"}, &worse)
+	assert.Equal(t, status, http.StatusOK)
+	assert.Assert(t, best.Score > worse.Score)
+}
+
+func TestServerPlay(t *testing.T) {
+	ts := initTestServer(t)
+
+	var resp PlayResponse
+	status := post(t, ts, "/play", PlayRequest{Objective: "efgh"}, &resp)
+	assert.Equal(t, status, http.StatusOK)
+	assert.Equal(t, resp.Status, "success")
+	assert.DeepEqual(t, resp.Turns, []Turn{
+		{Guess{"cdef", "--yy"}, 7},
+		{Guess{"efgh", "gggg"}, 1},
+	})
+
+	status = post(t, ts, "/play", PlayRequest{Objective: "efgh", MaxGuesses: 1}, &resp)
+	assert.Equal(t, status, http.StatusOK)
+	assert.Equal(t, resp.Status, "failure")
+}
+
+func TestServerBadRequests(t *testing.T) {
+	ts := initTestServer(t)
+
+	tests := []struct {
+		path string
+		body any
+	}{
+		{"/suggest", map[string]any{"unknown": 1}},
+		{"/suggest", SuggestRequest{History: []Guess{{Guess: "abc", Result: "gg-"}}}},
+		{"/suggest", SuggestRequest{History: []Guess{{Guess: "abcd", Result: "gg-"}}}},
+		{"/suggest", SuggestRequest{History: []Guess{{Guess: "abcd", Result: "gg-z"}}}},
+		{"/suggest", SuggestRequest{History: []Guess{{Guess: "abcd", Result: "gggg"}, {Guess: "bcde", Result: "gggg"}}}},
+		{"/filter", FilterRequest{Limit: -1}},
+		{"/score", ScoreRequest{Word: "abcdef"}},
+		{"/play", PlayRequest{Objective: "zzzz"}},
+		{"/play", PlayRequest{Objective: "abcd", MaxGuesses: -1}},
+	}
+	for _, test := range tests {
+		var resp ErrorResponse
+		status := post(t, ts, test.path, test.body, &resp)
+		assert.Equal(t, status, http.StatusBadRequest, "request: %v %v", test.path, test.body)
+		assert.Assert(t, resp.Error != "")
+	}
+}
+
+func TestServerRejectsGet(t *testing.T) {
+	ts := initTestServer(t)
+
+	resp, err := http.Get(ts.URL + "/suggest")
+	assert.NilError(t, err)
+	defer resp.Body.Close()
+	assert.Equal(t, resp.StatusCode, http.StatusMethodNotAllowed)
+}
@@ -0,0 +1,482 @@
+package server
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+	"unsafe"
+
+	gws "github.com/MorganR/go-wordle-solver/lib"
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+// SessionOptions limits the resources used by game sessions.
+type SessionOptions struct {
+	// The maximum number of live sessions. If zero, there is no limit.
+	MaxSessions int
+	// The maximum estimated memory used by all live sessions, in bytes. If zero, there is no limit.
+	MaxMemoryBytes int64
+	// How long a session may go unused before it expires. If zero, sessions never expire.
+	IdleTimeout time.Duration
+}
+
+// DefaultSessionOptions are the session options used by a new [Server].
+var DefaultSessionOptions = SessionOptions{
+	MaxSessions:    1000,
+	MaxMemoryBytes: 256 << 20,
+	IdleTimeout:    30 * time.Minute,
+}
+
+// The guessers that sessions may use.
+var validSessionGuessers = []string{"max_eliminations", "random"}
+
+// sessionBank holds a word bank and the guessers that sessions copy from.
+type sessionBank struct {
+	bank *gws.WordBank
+	// The guessers for this bank, by name. These are only used for copying.
+	guessers map[string]gws.Guesser
+	// The estimated memory used by a session with each guesser, by name.
+	sessionBytes map[string]int64
+}
+
+func initSessionBank(bank *gws.WordBank, scorer gws.MaxEliminationsScorer) *sessionBank {
+	random := gws.InitRandomGuesser(bank)
+	maxEliminations := gws.InitMaxScoreGuesser(bank, &scorer, gws.GuessModeAll)
+	words := bank.Words()
+	wordListBytes := int64(words.Len()) * int64(unsafe.Sizeof(gws.Word{}))
+	return &sessionBank{
+		bank: bank,
+		guessers: map[string]gws.Guesser{
+			"max_eliminations": &maxEliminations,
+			"random":           &random,
+		},
+		// The max eliminations guesser holds its possible and unguessed words, and its scorer holds
+		// another copy of the possible words. The random guesser only holds its possible words.
+		// Replaying a session builds a new guesser before the old one is released, so sessions may
+		// briefly hold two guessers.
+		sessionBytes: map[string]int64{
+			"max_eliminations": 2 * (3*wordListBytes + int64(unsafe.Sizeof(maxEliminations))),
+			"random":           2 * (wordListBytes + int64(unsafe.Sizeof(random))),
+		},
+	}
+}
+
+// AddWordBank makes the given bank available to sessions with the given name. **Be careful, this
+// is expensive to compute!** See [gws.InitMaxEliminationsScorer].
+//
+// Returns an error if a bank with this name already exists.
+func (s *Server) AddWordBank(name string, bank *gws.WordBank) error {
+	s.banksMu.RLock()
+	_, isPresent := s.banks[name]
+	s.banksMu.RUnlock()
+	if isPresent {
+		return fmt.Errorf("A word bank named %s already exists.", name)
+	}
+	scorer, err := gws.InitMaxEliminationsScorer(bank)
+	if err != nil {
+		return err
+	}
+	s.banksMu.Lock()
+	defer s.banksMu.Unlock()
+	if _, isPresent := s.banks[name]; isPresent {
+		return fmt.Errorf("A word bank named %s already exists.", name)
+	}
+	s.banks[name] = initSessionBank(bank, scorer)
+	return nil
+}
+
+// WordBankNames returns the names of the word banks available to sessions, in sorted order.
+func (s *Server) WordBankNames() []string {
+	s.banksMu.RLock()
+	defer s.banksMu.RUnlock()
+	names := maps.Keys(s.banks)
+	slices.Sort(names)
+	return names
+}
+
+// SetSessionOptions changes the limits on sessions. Existing sessions are kept, even if they
+// exceed the new limits.
+func (s *Server) SetSessionOptions(options SessionOptions) {
+	s.sessions.setOptions(options)
+}
+
+// CreateSessionRequest is the request body for creating a session.
+type CreateSessionRequest struct {
+	// The name of the word bank to use. Defaults to [DefaultWordBankName].
+	WordBank string `json:"word_bank"`
+	// The guesser to use, either "max_eliminations" (the default) or "random".
+	Guesser string `json:"guesser"`
+}
+
+// SessionResponse describes the current state of a session.
+type SessionResponse struct {
+	ID       string  `json:"id"`
+	WordBank string  `json:"word_bank"`
+	Guesser  string  `json:"guesser"`
+	History  []Guess `json:"history"`
+	// The suggested next guess, or empty if no words are possible.
+	Suggestion string `json:"suggestion,omitempty"`
+	// The number of words that are still possible.
+	NumPossibleWords int `json:"num_possible_words"`
+}
+
+// SessionHandler provides an [http.Handler] that serves game sessions:
+//
+//   - POST /sessions: Creates a session from a [CreateSessionRequest].
+//   - GET /sessions/{id}: Gets the session's state.
+//   - POST /sessions/{id}/guesses: Adds a [Guess] to the session's history.
+//   - POST /sessions/{id}/undo: Removes the most recent guess from the session's history.
+//   - DELETE /sessions/{id}: Deletes the session.
+//
+// All but DELETE respond with a [SessionResponse]. Each session holds its own copy of a
+// [gws.Guesser], and is safe to use from concurrent requests. Sessions expire after a period of
+// inactivity, and new sessions are rejected with a 503 once the limits set by
+// [Server.SetSessionOptions] are reached.
+func (s *Server) SessionHandler() http.Handler {
+	return http.HandlerFunc(s.serveSessions)
+}
+
+func (s *Server) serveSessions(w http.ResponseWriter, r *http.Request) {
+	path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/sessions"), "/")
+	if path == "" {
+		if r.Method != http.MethodPost {
+			writeMethodNotAllowed(w, http.MethodPost)
+			return
+		}
+		var req CreateSessionRequest
+		if err := decodeJSON(w, r, &req); err != nil {
+			writeError(w, err)
+			return
+		}
+		resp, err := s.createSession(&req)
+		if err != nil {
+			writeError(w, err)
+			return
+		}
+		writeJSON(w, http.StatusCreated, resp)
+		return
+	}
+
+	id, action, _ := strings.Cut(path, "/")
+	var resp SessionResponse
+	var err error
+	switch action {
+	case "":
+		switch r.Method {
+		case http.MethodGet:
+			resp, err = s.withSession(id, (*session).state)
+		case http.MethodDelete:
+			if !s.sessions.remove(id) {
+				writeError(w, notFoundf("Session %s does not exist.", id))
+				return
+			}
+			w.WriteHeader(http.StatusNoContent)
+			return
+		default:
+			writeMethodNotAllowed(w, http.MethodGet, http.MethodDelete)
+			return
+		}
+	case "guesses":
+		if r.Method != http.MethodPost {
+			writeMethodNotAllowed(w, http.MethodPost)
+			return
+		}
+		var req Guess
+		if err := decodeJSON(w, r, &req); err != nil {
+			writeError(w, err)
+			return
+		}
+		resp, err = s.withSession(id, func(sess *session) (SessionResponse, error) {
+			return sess.addGuess(req)
+		})
+	case "undo":
+		if r.Method != http.MethodPost {
+			writeMethodNotAllowed(w, http.MethodPost)
+			return
+		}
+		resp, err = s.withSession(id, (*session).undo)
+	default:
+		err = notFoundf("Unknown session action: %s", action)
+	}
+	if err != nil {
+		writeError(w, err)
+		return
+	}
+	writeJSON(w, http.StatusOK, resp)
+}
+
+func (s *Server) createSession(req *CreateSessionRequest) (SessionResponse, error) {
+	bankName := req.WordBank
+	if bankName == "" {
+		bankName = DefaultWordBankName
+	}
+	guesserName := req.Guesser
+	if guesserName == "" {
+		guesserName = validSessionGuessers[0]
+	}
+	s.banksMu.RLock()
+	bank, isPresent := s.banks[bankName]
+	s.banksMu.RUnlock()
+	if !isPresent {
+		return SessionResponse{}, badRequestf("Unknown word bank %s. Available word banks: %v.", bankName, s.WordBankNames())
+	}
+	prototype, isPresent := bank.guessers[guesserName]
+	if !isPresent {
+		return SessionResponse{}, badRequestf("Unknown guesser %s. Available guessers: %v.", guesserName, validSessionGuessers)
+	}
+	id, err := newSessionID()
+	if err != nil {
+		return SessionResponse{}, err
+	}
+	sess := &session{
+		id:          id,
+		bankName:    bankName,
+		guesserName: guesserName,
+		bank:        bank,
+		prototype:   prototype,
+		numBytes:    bank.sessionBytes[guesserName],
+	}
+	sess.replay()
+	// The session can be used by other requests as soon as it's added.
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	if err := s.sessions.add(sess); err != nil {
+		return SessionResponse{}, err
+	}
+	return sess.stateLocked(), nil
+}
+
+// withSession calls fn with the given session while holding its lock.
+func (s *Server) withSession(id string, fn func(*session) (SessionResponse, error)) (SessionResponse, error) {
+	sess := s.sessions.get(id)
+	if sess == nil {
+		return SessionResponse{}, notFoundf("Session %s does not exist.", id)
+	}
+	sess.mu.Lock()
+	defer sess.mu.Unlock()
+	return fn(sess)
+}
+
+func newSessionID() (string, error) {
+	var id [16]byte
+	if _, err := rand.Read(id[:]); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(id[:]), nil
+}
+
+// session is a single game, played with its own copy of a guesser.
+type session struct {
+	id          string
+	bankName    string
+	guesserName string
+	bank        *sessionBank
+	// The guesser this session's guesser was copied from. Used to replay the history.
+	prototype gws.Guesser
+	// The estimated memory used by this session.
+	numBytes int64
+
+	// Guards all following fields.
+	mu         sync.Mutex
+	guesser    gws.Guesser
+	history    []gws.GuessResult
+	suggestion gws.Optional[gws.Word]
+}
+
+// state returns the current state of this session.
+func (sess *session) state() (SessionResponse, error) {
+	return sess.stateLocked(), nil
+}
+
+func (sess *session) stateLocked() SessionResponse {
+	history := make([]Guess, len(sess.history))
+	for i, result := range sess.history {
+		history[i] = Guess{result.Guess.String(), gws.LetterResultsString(result.Results)}
+	}
+	resp := SessionResponse{
+		ID:               sess.id,
+		WordBank:         sess.bankName,
+		Guesser:          sess.guesserName,
+		History:          history,
+		NumPossibleWords: sess.guesser.PossibleWords().Len(),
+	}
+	if sess.suggestion.HasValue() {
+		resp.Suggestion = sess.suggestion.Value().String()
+	}
+	return resp
+}
+
+// addGuess adds the given guess to the history. If the guess conflicts with the history, the
+// session is unchanged.
+func (sess *session) addGuess(guess Guess) (SessionResponse, error) {
+	result, err := parseGuess(sess.bank.bank, guess)
+	if err != nil {
+		return SessionResponse{}, badRequestf("Invalid guess: %s", err)
+	}
+	if err := sess.guesser.Update(&result); err != nil {
+		// The guesser is unchanged if the update fails.
+		return SessionResponse{}, badRequestf("%s", err)
+	}
+	sess.history = append(sess.history, result)
+	sess.suggestion = sess.guesser.SelectNextGuess()
+	return sess.stateLocked(), nil
+}
+
+// undo removes the most recent guess from the history.
+func (sess *session) undo() (SessionResponse, error) {
+	if len(sess.history) == 0 {
+		return SessionResponse{}, badRequestf("There are no guesses to undo.")
+	}
+	sess.history = sess.history[:len(sess.history)-1]
+	sess.replay()
+	return sess.stateLocked(), nil
+}
+
+// replay rebuilds this session's guesser from its prototype and history.
+func (sess *session) replay() {
+	sess.guesser = sess.prototype.Copy()
+	sess.guesser.Reset()
+	for i := range sess.history {
+		// The history was already applied successfully, so this can't fail.
+		sess.guesser.Update(&sess.history[i])
+	}
+	sess.suggestion = sess.guesser.SelectNextGuess()
+}
+
+// How often expired sessions are removed in the background.
+const sessionReapInterval = time.Minute
+
+// sessionStore tracks live sessions. It is safe for concurrent use.
+type sessionStore struct {
+	mu       sync.Mutex
+	options  SessionOptions
+	now      func() time.Time
+	sessions map[string]*storedSession
+	// The estimated memory used by all sessions in the store.
+	numBytes int64
+
+	// Closed to stop the reaper started by startReaper.
+	stopReaper chan struct{}
+	stopOnce   sync.Once
+}
+
+type storedSession struct {
+	session  *session
+	lastUsed time.Time
+}
+
+func initSessionStore(options SessionOptions, now func() time.Time) sessionStore {
+	return sessionStore{
+		options:    options,
+		now:        now,
+		sessions:   make(map[string]*storedSession),
+		stopReaper: make(chan struct{}),
+	}
+}
+
+// startReaper removes expired sessions every interval, until stop is called.
+func (store *sessionStore) startReaper(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				store.removeExpired()
+			case <-store.stopReaper:
+				return
+			}
+		}
+	}()
+}
+
+// stop stops the reaper. It is safe to call more than once.
+func (store *sessionStore) stop() {
+	store.stopOnce.Do(func() { close(store.stopReaper) })
+}
+
+func (store *sessionStore) setOptions(options SessionOptions) {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.options = options
+}
+
+// add adds the given session, after removing any expired sessions.
+//
+// Returns an error if this would exceed the store's limits.
+func (store *sessionStore) add(sess *session) error {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.removeExpiredLocked()
+	if store.options.MaxSessions > 0 && len(store.sessions) >= store.options.MaxSessions {
+		return unavailablef("Too many sessions. Try again later.")
+	}
+	if store.options.MaxMemoryBytes > 0 && store.numBytes+sess.numBytes > store.options.MaxMemoryBytes {
+		return unavailablef("Sessions are using too much memory. Try again later.")
+	}
+	store.sessions[sess.id] = &storedSession{sess, store.now()}
+	store.numBytes += sess.numBytes
+	return nil
+}
+
+// get returns the session with the given ID, or nil if it does not exist or has expired.
+//
+// This counts as activity for the session.
+func (store *sessionStore) get(id string) *session {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	stored, isPresent := store.sessions[id]
+	if !isPresent {
+		return nil
+	}
+	now := store.now()
+	if store.isExpiredLocked(stored, now) {
+		store.removeLocked(id)
+		return nil
+	}
+	stored.lastUsed = now
+	return stored.session
+}
+
+// remove removes the session with the given ID.
+//
+// Returns true if the session existed and had not expired.
+func (store *sessionStore) remove(id string) bool {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	stored, isPresent := store.sessions[id]
+	if !isPresent {
+		return false
+	}
+	store.removeLocked(id)
+	return !store.isExpiredLocked(stored, store.now())
+}
+
+func (store *sessionStore) isExpiredLocked(stored *storedSession, now time.Time) bool {
+	return store.options.IdleTimeout > 0 && now.Sub(stored.lastUsed) > store.options.IdleTimeout
+}
+
+func (store *sessionStore) removeExpired() {
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	store.removeExpiredLocked()
+}
+
+func (store *sessionStore) removeExpiredLocked() {
+	now := store.now()
+	for id, stored := range store.sessions {
+		if store.isExpiredLocked(stored, now) {
+			store.removeLocked(id)
+		}
+	}
+}
+
+func (store *sessionStore) removeLocked(id string) {
+	store.numBytes -= store.sessions[id].session.numBytes
+	delete(store.sessions, id)
+}
@@ -0,0 +1,219 @@
+package server
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	gws "github.com/MorganR/go-wordle-solver/lib"
+	"gotest.tools/v3/assert"
+)
+
+func createSession(t *testing.T, ts *httptest.Server, req CreateSessionRequest) SessionResponse {
+	t.Helper()
+	var resp SessionResponse
+	status := post(t, ts, "/sessions", req, &resp)
+	assert.Equal(t, status, http.StatusCreated)
+	return resp
+}
+
+func TestSessionLifecycle(t *testing.T) {
+	_, ts := initTestServerAndHandler(t)
+
+	created := createSession(t, ts, CreateSessionRequest{})
+	assert.Assert(t, created.ID != "")
+	assert.DeepEqual(t, created, SessionResponse{
+		ID:               created.ID,
+		WordBank:         DefaultWordBankName,
+		Guesser:          "max_eliminations",
+		History:          []Guess{},
+		Suggestion:       "cdef",
+		NumPossibleWords: 7,
+	})
+	path := "/sessions/" + created.ID
+
+	var resp SessionResponse
+	status := post(t, ts, path+"/guesses", Guess{"CDEF", "--yy"}, &resp)
+	assert.Equal(t, status, http.StatusOK)
+	assert.DeepEqual(t, resp.History, []Guess{{"cdef", "--yy"}})
+	assert.Equal(t, resp.Suggestion, "efgh")
+	assert.Equal(t, resp.NumPossibleWords, 1)
+
+	status = doRequest(t, ts, http.MethodGet, path, nil, &resp)
+	assert.Equal(t, status, http.StatusOK)
+	assert.DeepEqual(t, resp.History, []Guess{{"cdef", "--yy"}})
+	assert.Equal(t, resp.Suggestion, "efgh")
+
+	status = post(t, ts, path+"/undo", nil, &resp)
+	assert.Equal(t, status, http.StatusOK)
+	assert.DeepEqual(t, resp, created)
+
+	status = doRequest(t, ts, http.MethodDelete, path, nil, nil)
+	assert.Equal(t, status, http.StatusNoContent)
+
+	var errResp ErrorResponse
+	status = doRequest(t, ts, http.MethodGet, path, nil, &errResp)
+	assert.Equal(t, status, http.StatusNotFound)
+	status = doRequest(t, ts, http.MethodDelete, path, nil, &errResp)
+	assert.Equal(t, status, http.StatusNotFound)
+}
+
+func TestSessionRejectsConflictingGuess(t *testing.T) {
+	_, ts := initTestServerAndHandler(t)
+	created := createSession(t, ts, CreateSessionRequest{})
+	path := "/sessions/" + created.ID
+
+	var resp SessionResponse
+	status := post(t, ts, path+"/guesses", Guess{"cdef", "--yy"}, &resp)
+	assert.Equal(t, status, http.StatusOK)
+
+	var errResp ErrorResponse
+	status = post(t, ts, path+"/guesses", Guess{"cdef", "gggg"}, &errResp)
+	assert.Equal(t, status, http.StatusBadRequest)
+	assert.Assert(t, errResp.Error != "")
+
+	// The session is unchanged.
+	status = doRequest(t, ts, http.MethodGet, path, nil, &resp)
+	assert.Equal(t, status, http.StatusOK)
+	assert.DeepEqual(t, resp.History, []Guess{{"cdef", "--yy"}})
+	assert.Equal(t, resp.NumPossibleWords, 1)
+}
+
+func TestSessionBadRequests(t *testing.T) {
+	_, ts := initTestServerAndHandler(t)
+	created := createSession(t, ts, CreateSessionRequest{})
+	path := "/sessions/" + created.ID
+
+	var errResp ErrorResponse
+	assert.Equal(t, post(t, ts, "/sessions", CreateSessionRequest{WordBank: "unknown"}, &errResp), http.StatusBadRequest)
+	assert.Equal(t, post(t, ts, "/sessions", CreateSessionRequest{Guesser: "unknown"}, &errResp), http.StatusBadRequest)
+	assert.Equal(t, post(t, ts, path+"/guesses", Guess{"abc", "gg-"}, &errResp), http.StatusBadRequest)
+	assert.Equal(t, post(t, ts, path+"/guesses", Guess{"abcd", "gg-z"}, &errResp), http.StatusBadRequest)
+	assert.Equal(t, post(t, ts, path+"/undo", nil, &errResp), http.StatusBadRequest)
+	assert.Equal(t, post(t, ts, path+"/unknown", nil, &errResp), http.StatusNotFound)
+	assert.Equal(t, post(t, ts, "/sessions/unknown/guesses", Guess{"abcd", "gggg"}, &errResp), http.StatusNotFound)
+	assert.Equal(t, doRequest(t, ts, http.MethodGet, "/sessions", nil, &errResp), http.StatusMethodNotAllowed)
+	assert.Equal(t, doRequest(t, ts, http.MethodPut, path, nil, &errResp), http.StatusMethodNotAllowed)
+}
+
+func TestSessionWithOtherWordBank(t *testing.T) {
+	s, ts := initTestServerAndHandler(t)
+	bank, err := gws.WordBankFromSlice([]string{"wxyz", "zyxw"})
+	assert.NilError(t, err)
+	assert.NilError(t, s.AddWordBank("other", &bank))
+	assert.ErrorContains(t, s.AddWordBank("other", &bank), "already exists")
+	assert.DeepEqual(t, s.WordBankNames(), []string{DefaultWordBankName, "other"})
+
+	created := createSession(t, ts, CreateSessionRequest{WordBank: "other", Guesser: "random"})
+	assert.Equal(t, created.WordBank, "other")
+	assert.Equal(t, created.Guesser, "random")
+	assert.Equal(t, created.NumPossibleWords, 2)
+}
+
+func TestSessionExpiresAfterInactivity(t *testing.T) {
+	s, ts := initTestServerAndHandler(t)
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.sessions.now = func() time.Time { return now }
+	s.SetSessionOptions(SessionOptions{IdleTimeout: time.Minute})
+
+	created := createSession(t, ts, CreateSessionRequest{})
+	path := "/sessions/" + created.ID
+
+	now = now.Add(time.Minute)
+	var resp SessionResponse
+	assert.Equal(t, doRequest(t, ts, http.MethodGet, path, nil, &resp), http.StatusOK)
+
+	now = now.Add(time.Minute + time.Second)
+	var errResp ErrorResponse
+	assert.Equal(t, doRequest(t, ts, http.MethodGet, path, nil, &errResp), http.StatusNotFound)
+	assert.Equal(t, len(s.sessions.sessions), 0)
+	assert.Equal(t, s.sessions.numBytes, int64(0))
+}
+
+func TestSessionReaperRemovesExpiredSessions(t *testing.T) {
+	s, ts := initTestServerAndHandler(t)
+	var nowMu sync.Mutex
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.sessions.now = func() time.Time {
+		nowMu.Lock()
+		defer nowMu.Unlock()
+		return now
+	}
+	s.SetSessionOptions(SessionOptions{IdleTimeout: time.Minute})
+	createSession(t, ts, CreateSessionRequest{})
+	createSession(t, ts, CreateSessionRequest{Guesser: "random"})
+
+	nowMu.Lock()
+	now = now.Add(2 * time.Minute)
+	nowMu.Unlock()
+	s.sessions.startReaper(time.Millisecond)
+
+	for i := 0; i < 1000; i++ {
+		s.sessions.mu.Lock()
+		numSessions := len(s.sessions.sessions)
+		numBytes := s.sessions.numBytes
+		s.sessions.mu.Unlock()
+		if numSessions == 0 {
+			assert.Equal(t, numBytes, int64(0))
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+	t.Fatal("Expired sessions were not removed.")
+}
+
+func TestSessionLimits(t *testing.T) {
+	s, ts := initTestServerAndHandler(t)
+	now := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	s.sessions.now = func() time.Time { return now }
+	s.SetSessionOptions(SessionOptions{MaxSessions: 2, IdleTimeout: time.Minute})
+
+	first := createSession(t, ts, CreateSessionRequest{})
+	createSession(t, ts, CreateSessionRequest{})
+	var errResp ErrorResponse
+	assert.Equal(t, post(t, ts, "/sessions", CreateSessionRequest{}, &errResp), http.StatusServiceUnavailable)
+
+	// Deleting a session makes room for another.
+	assert.Equal(t, doRequest(t, ts, http.MethodDelete, "/sessions/"+first.ID, nil, nil), http.StatusNoContent)
+	createSession(t, ts, CreateSessionRequest{})
+
+	// As does letting sessions expire.
+	now = now.Add(2 * time.Minute)
+	createSession(t, ts, CreateSessionRequest{})
+
+	sessionBytes := s.banks[DefaultWordBankName].sessionBytes["max_eliminations"]
+	s.SetSessionOptions(SessionOptions{MaxMemoryBytes: 2 * sessionBytes})
+	createSession(t, ts, CreateSessionRequest{})
+	assert.Equal(t, post(t, ts, "/sessions", CreateSessionRequest{}, &errResp), http.StatusServiceUnavailable)
+}
+
+func TestSessionConcurrentGuesses(t *testing.T) {
+	_, ts := initTestServerAndHandler(t)
+	created := createSession(t, ts, CreateSessionRequest{})
+	path := "/sessions/" + created.ID
+
+	const numRequests = 8
+	var wg sync.WaitGroup
+	statuses := make([]int, numRequests)
+	errs := make([]error, numRequests)
+	for i := 0; i < numRequests; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			var resp SessionResponse
+			statuses[i], errs[i] = sendRequest(ts, http.MethodPost, path+"/guesses", Guess{"ghij", "----"}, &resp)
+		}(i)
+	}
+	wg.Wait()
+	for i := range statuses {
+		assert.NilError(t, errs[i])
+		assert.Equal(t, statuses[i], http.StatusOK)
+	}
+
+	var resp SessionResponse
+	assert.Equal(t, doRequest(t, ts, http.MethodGet, path, nil, &resp), http.StatusOK)
+	assert.Equal(t, len(resp.History), numRequests)
+	assert.Equal(t, resp.NumPossibleWords, 3)
+}
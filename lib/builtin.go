@@ -0,0 +1,94 @@
+package go_wordle_solver
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+//go:embed data/*.txt
+var builtinData embed.FS
+
+// The files backing each built-in word list.
+var builtinWordListFiles = map[string]string{
+	// The answers to the original Wordle, in alphabetical order.
+	"wordle-answers": "data/wordle-answers.txt",
+	// All words accepted as guesses by the original Wordle.
+	"wordle-words": "data/wordle-words.txt",
+	// A curated list of common five letter words.
+	"improved": "data/improved-words.txt",
+	// A smaller list of the most common five letter words.
+	"best": "data/best-words.txt",
+	// 1000 words from "improved", in a random order.
+	"1000-improved-shuffled": "data/1000-improved-words-shuffled.txt",
+	// 1000 words from "wordle-words", in a random order.
+	"1000-wordle-shuffled": "data/1000-wordle-words-shuffled.txt",
+}
+
+// The equation lengths of the built-in equation lists, which are generated by [Equations] instead
+// of being stored.
+var builtinEquationLengths = map[string]int{
+	// All equations for Nerdle.
+	"nerdle": 8,
+	// All equations for Mini Nerdle.
+	"mini-nerdle": 6,
+}
+
+// BuiltinWordListNames returns the names of all the built-in word lists, in alphabetical order.
+func BuiltinWordListNames() []string {
+	names := append(maps.Keys(builtinWordListFiles), maps.Keys(builtinEquationLengths)...)
+	slices.Sort(names)
+	return names
+}
+
+// IsBuiltinWordList returns true iff there is a built-in word list with the given name.
+func IsBuiltinWordList(name string) bool {
+	_, isPresent := builtinWordListFiles[name]
+	_, isEquations := builtinEquationLengths[name]
+	return isPresent || isEquations
+}
+
+// IsBuiltinEquationList returns true iff there is a built-in list of equations with the given name.
+// See [Equations].
+func IsBuiltinEquationList(name string) bool {
+	_, isEquations := builtinEquationLengths[name]
+	return isEquations
+}
+
+// OpenBuiltinWordList provides a reader for the built-in word list with the given name.
+//
+// The list has one word per line. Returns an error if there is no list with this name.
+func OpenBuiltinWordList(name string) (io.Reader, error) {
+	if length, isEquations := builtinEquationLengths[name]; isEquations {
+		equations, err := Equations(length)
+		if err != nil {
+			return nil, err
+		}
+		return strings.NewReader(strings.Join(equations, "\n")), nil
+	}
+	path, isPresent := builtinWordListFiles[name]
+	if !isPresent {
+		return nil, fmt.Errorf("There is no built-in word list named %s. Options: %v.", name, BuiltinWordListNames())
+	}
+	data, err := builtinData.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(data), nil
+}
+
+// BuiltinWordBank constructs a [WordBank] from the built-in word list with the given name.
+//
+// See [BuiltinWordListNames] for the available names.
+func BuiltinWordBank(name string) (WordBank, error) {
+	r, err := OpenBuiltinWordList(name)
+	if err != nil {
+		return WordBank{}, err
+	}
+	return WordBankFromReader(r)
+}
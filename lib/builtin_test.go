@@ -0,0 +1,42 @@
+package go_wordle_solver
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestBuiltinWordBank(t *testing.T) {
+	for _, name := range BuiltinWordListNames() {
+		bank, err := BuiltinWordBank(name)
+		assert.NilError(t, err, name)
+		wantLength := 5
+		if length, isEquations := builtinEquationLengths[name]; isEquations {
+			wantLength = length
+		}
+		assert.Equal(t, bank.WordLength(), uint8(wantLength), name)
+	}
+
+	answers, err := BuiltinWordBank("wordle-answers")
+	assert.NilError(t, err)
+	pw := answers.Words()
+	assert.Equal(t, pw.Len(), 2315)
+}
+
+func TestBuiltinWordBankUnknownName(t *testing.T) {
+	assert.Assert(t, !IsBuiltinWordList("nope"))
+
+	_, err := BuiltinWordBank("nope")
+	assert.ErrorContains(t, err, "There is no built-in word list named nope.")
+}
+
+func TestBuiltinEquationLists(t *testing.T) {
+	assert.Assert(t, IsBuiltinEquationList("nerdle"))
+	assert.Assert(t, IsBuiltinWordList("nerdle"))
+	assert.Assert(t, !IsBuiltinEquationList("improved"))
+
+	bank, err := BuiltinWordBank("mini-nerdle")
+	assert.NilError(t, err)
+	pw := bank.Words()
+	assert.Equal(t, pw.Len(), 206)
+}
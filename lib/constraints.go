@@ -0,0 +1,345 @@
+package go_wordle_solver
+
+import (
+	"fmt"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+// This file provides methods to add restrictions directly, rather than deriving them from a
+// [GuessResult]. This is useful when facts are known from elsewhere, such as "it starts with S" or
+// "there are no double letters".
+//
+// Each method returns an error if the new restriction contradicts what is already known, in which
+// case the restrictions are unchanged. If the restriction conflicts, the error is a
+// [*RestrictionError].
+
+// SetLetterAt adds the restriction that the given letter is at the given location.
+func (self *WordRestrictions) SetLetterAt(letter rune, location uint8) error {
+	if err := self.checkLocation(location); err != nil {
+		return err
+	}
+	return self.applyLetterUpdate(letter, OptionalOf(location), func(restrictions *WordRestrictions) error {
+		return restrictions.setLetterAt(letter, location)
+	})
+}
+
+// SetLetterNotAt adds the restriction that the given letter is not at the given location.
+//
+// Unlike a [LetterResultPresentNotHere] result, this does not imply that the letter is in the
+// word.
+func (self *WordRestrictions) SetLetterNotAt(letter rune, location uint8) error {
+	if err := self.checkLocation(location); err != nil {
+		return err
+	}
+	return self.applyLetterUpdate(letter, OptionalOf(location), func(restrictions *WordRestrictions) error {
+		return restrictions.setLetterNotAt(letter, location)
+	})
+}
+
+// SetLetterMinCount adds the restriction that the given letter appears at least count times.
+func (self *WordRestrictions) SetLetterMinCount(letter rune, count uint8) error {
+	return self.applyLetterUpdate(letter, Optional[uint8]{}, func(restrictions *WordRestrictions) error {
+		return restrictions.setLetterMinCount(letter, count)
+	})
+}
+
+// SetLetterMaxCount adds the restriction that the given letter appears at most count times.
+//
+// A count of 0 is the same as [WordRestrictions.ExcludeLetter].
+func (self *WordRestrictions) SetLetterMaxCount(letter rune, count uint8) error {
+	return self.applyLetterUpdate(letter, Optional[uint8]{}, func(restrictions *WordRestrictions) error {
+		return restrictions.setLetterMaxCount(letter, count)
+	})
+}
+
+// SetLetterCount adds the restriction that the given letter appears exactly count times.
+func (self *WordRestrictions) SetLetterCount(letter rune, count uint8) error {
+	return self.applyLetterUpdate(letter, Optional[uint8]{}, func(restrictions *WordRestrictions) error {
+		if err := restrictions.setLetterMinCount(letter, count); err != nil {
+			return err
+		}
+		return restrictions.setLetterMaxCount(letter, count)
+	})
+}
+
+// ExcludeLetter adds the restriction that the given letter is not in the word.
+func (self *WordRestrictions) ExcludeLetter(letter rune) error {
+	return self.applyLetterUpdate(letter, Optional[uint8]{}, func(restrictions *WordRestrictions) error {
+		return restrictions.excludeLetter(letter)
+	})
+}
+
+// SetNoRepeatedLetters adds the restriction that each letter appears at most once.
+func (self *WordRestrictions) SetNoRepeatedLetters() error {
+	return self.apply(restrictionUpdate{
+		apply: func(restrictions *WordRestrictions) error {
+			return restrictions.setNoRepeatedLetters()
+		},
+	})
+}
+
+// applyLetterUpdate applies an update that adds restrictions directly for the given letter.
+func (self *WordRestrictions) applyLetterUpdate(letter rune, location Optional[uint8], fn func(restrictions *WordRestrictions) error) error {
+	return self.apply(restrictionUpdate{
+		apply: func(restrictions *WordRestrictions) error {
+			if err := fn(restrictions); err != nil {
+				return newRestrictionError(letter, location, err)
+			}
+			return nil
+		},
+	})
+}
+
+func (self *WordRestrictions) setLetterAt(letter rune, location uint8) error {
+	if slices.Contains(self.notPresentLetters, letter) {
+		return fmt.Errorf("Can't set the letter %c at %v since it's already known not to be in the word.", letter, location)
+	}
+	if otherLetter, isKnown := self.knownLetterAt(location); isKnown && otherLetter != letter {
+		return fmt.Errorf("Can't set the letter %c at %v since %c is already known to be there.", letter, location, otherLetter)
+	}
+	presence, err := self.presentLetterFor(letter)
+	if err != nil {
+		return err
+	}
+	if err := presence.setMustBeAt(location); err != nil {
+		return err
+	}
+	return self.inferFromLimits()
+}
+
+func (self *WordRestrictions) setLetterNotAt(letter rune, location uint8) error {
+	if slices.Contains(self.notPresentLetters, letter) {
+		return nil
+	}
+	if presence, isPresent := self.presentLetters[letter]; isPresent {
+		if err := presence.setMustNotBeAt(location); err != nil {
+			return err
+		}
+		return self.inferFromLimits()
+	}
+	locations := self.excludedLocations[letter]
+	if slices.Contains(locations, location) {
+		return nil
+	}
+	if len(locations)+1 == int(self.wordLength) {
+		// The letter can't be anywhere.
+		return self.excludeLetter(letter)
+	}
+	locations = append(slices.Clip(locations), location)
+	slices.Sort(locations)
+	if self.excludedLocations == nil {
+		self.excludedLocations = make(map[rune][]uint8)
+	}
+	self.excludedLocations[letter] = locations
+	return nil
+}
+
+func (self *WordRestrictions) setLetterMinCount(letter rune, count uint8) error {
+	if count == 0 {
+		return nil
+	}
+	if slices.Contains(self.notPresentLetters, letter) {
+		return fmt.Errorf("Can't require the letter %c since it's already known not to be in the word.", letter)
+	}
+	if maxCount := self.maxCount(letter); count > maxCount {
+		return fmt.Errorf("Can't require the letter %c %v times since it can appear at most %v times.", letter, count, maxCount)
+	}
+	presence, err := self.presentLetterFor(letter)
+	if err != nil {
+		return err
+	}
+	if presence.maybeRequiredCount.HasValue() && count > presence.maybeRequiredCount.Value() {
+		return fmt.Errorf("Can't require the letter %c %v times since it's already known to appear exactly %v times.", letter, count, presence.maybeRequiredCount.Value())
+	}
+	if err := presence.possiblyBumpMinCount(count); err != nil {
+		return err
+	}
+	return self.inferFromLimits()
+}
+
+func (self *WordRestrictions) setLetterMaxCount(letter rune, count uint8) error {
+	if count == 0 {
+		return self.excludeLetter(letter)
+	}
+	if count >= self.maxCount(letter) || slices.Contains(self.notPresentLetters, letter) {
+		return nil
+	}
+	if presence, isPresent := self.presentLetters[letter]; isPresent && presence.minCount > count {
+		return fmt.Errorf("Can't limit the letter %c to %v times since it appears at least %v times.", letter, count, presence.minCount)
+	}
+	if self.maxCounts == nil {
+		self.maxCounts = make(map[rune]uint8)
+	}
+	self.maxCounts[letter] = count
+	return self.inferFromLimits()
+}
+
+func (self *WordRestrictions) excludeLetter(letter rune) error {
+	if _, isPresent := self.presentLetters[letter]; isPresent {
+		return fmt.Errorf("Can't exclude the letter %c since it's already known to be in the word.", letter)
+	}
+	self.addNotPresentLetter(letter)
+	return nil
+}
+
+func (self *WordRestrictions) setNoRepeatedLetters() error {
+	for _, letter := range self.sortedPresentLetters() {
+		if presence := self.presentLetters[letter]; presence.minCount > 1 {
+			return newRestrictionError(letter, Optional[uint8]{}, fmt.Errorf("Can't forbid repeated letters since the letter %c appears at least %v times.", letter, presence.minCount))
+		}
+	}
+	self.noRepeatedLetters = true
+	// These limits are now redundant.
+	self.maxCounts = nil
+	return self.inferFromLimits()
+}
+
+func (self *WordRestrictions) checkLocation(location uint8) error {
+	if location >= self.wordLength {
+		return fmt.Errorf("The location %v is out of range for words of length %v.", location, self.wordLength)
+	}
+	return nil
+}
+
+// presentLetterFor returns the [presentLetter] for the given letter, creating it if needed.
+//
+// Any locations the letter was already known not to be at are applied to the new [presentLetter].
+func (self *WordRestrictions) presentLetterFor(letter rune) (*presentLetter, error) {
+	if presence, isPresent := self.presentLetters[letter]; isPresent {
+		return presence, nil
+	}
+	presence := newPresentLetter(self.wordLength)
+	self.presentLetters[letter] = presence
+	for _, location := range self.excludedLocations[letter] {
+		if err := presence.setMustNotBeAt(location); err != nil {
+			return nil, err
+		}
+	}
+	delete(self.excludedLocations, letter)
+	return presence, nil
+}
+
+// addNotPresentLetter records that the given letter is not in the word.
+func (self *WordRestrictions) addNotPresentLetter(letter rune) {
+	if !slices.Contains(self.notPresentLetters, letter) {
+		self.notPresentLetters = append(self.notPresentLetters, letter)
+	}
+	// These are now redundant.
+	delete(self.excludedLocations, letter)
+	delete(self.maxCounts, letter)
+}
+
+// maxCount returns the maximum number of times the given letter can appear in the word.
+func (self *WordRestrictions) maxCount(letter rune) uint8 {
+	maxCount := self.wordLength
+	if self.noRepeatedLetters {
+		maxCount = 1
+	}
+	if limit, hasLimit := self.maxCounts[letter]; hasLimit && limit < maxCount {
+		maxCount = limit
+	}
+	return maxCount
+}
+
+// inferFromLimits applies the maximum letter counts to the present letters, and marks the known
+// location of each letter as excluded for all other letters, until nothing more can be inferred.
+//
+// Returns a [*RestrictionError] if the restrictions can't all be satisfied.
+func (self *WordRestrictions) inferFromLimits() error {
+	for changed := true; changed; {
+		changed = false
+		totalMinCount := 0
+		// Check the letters in order so that conflicts are reported consistently.
+		letters := self.sortedPresentLetters()
+		for _, letter := range letters {
+			presence := self.presentLetters[letter]
+			maxCount := self.maxCount(letter)
+			if presence.minCount > maxCount {
+				return newRestrictionError(letter, Optional[uint8]{}, fmt.Errorf("The letter %c must appear at least %v times, but can appear at most %v times.", letter, presence.minCount, maxCount))
+			}
+			if presence.minCount == maxCount && !presence.maybeRequiredCount.HasValue() {
+				if err := presence.setRequiredCount(maxCount); err != nil {
+					return newRestrictionError(letter, Optional[uint8]{}, err)
+				}
+				changed = true
+			}
+			totalMinCount += int(presence.minCount)
+		}
+		if totalMinCount > int(self.wordLength) {
+			return &RestrictionError{
+				Reason: fmt.Sprintf("The known letters need at least %v locations, but words only have %v.", totalMinCount, self.wordLength),
+			}
+		}
+
+		for location := uint8(0); location < self.wordLength; location++ {
+			letter, isKnown := self.knownLetterAt(location)
+			if !isKnown {
+				continue
+			}
+			for _, otherLetter := range letters {
+				presence := self.presentLetters[otherLetter]
+				if otherLetter == letter || presence.state(location) == llsNotHere {
+					continue
+				}
+				if err := presence.setMustNotBeAt(location); err != nil {
+					return newRestrictionError(otherLetter, OptionalOf(location), fmt.Errorf("The letters %c and %c can't both be at index %v.", letter, otherLetter, location))
+				}
+				changed = true
+			}
+		}
+	}
+	return nil
+}
+
+// sortedPresentLetters returns the letters known to be present, in order.
+func (self *WordRestrictions) sortedPresentLetters() []rune {
+	letters := maps.Keys(self.presentLetters)
+	slices.Sort(letters)
+	return letters
+}
+
+// satisfiesLimits returns true iff the given word satisfies the restrictions that aren't tracked by
+// the present and not present letters.
+func (self *WordRestrictions) satisfiesLimits(word Word) bool {
+	if len(self.excludedLocations) == 0 && len(self.maxCounts) == 0 && !self.noRepeatedLetters {
+		return true
+	}
+	for letter, locations := range self.excludedLocations {
+		for _, location := range locations {
+			if word.At(int(location)) == letter {
+				return false
+			}
+		}
+	}
+	for letter, maxCount := range self.maxCounts {
+		if countLetter(word, letter) > int(maxCount) {
+			return false
+		}
+	}
+	if self.noRepeatedLetters {
+		length := word.Len()
+		for i := 1; i < length; i++ {
+			letter := word.At(i)
+			for j := 0; j < i; j++ {
+				if word.At(j) == letter {
+					return false
+				}
+			}
+		}
+	}
+	return true
+}
+
+// countLetter returns the number of times the letter appears in the word.
+func countLetter(word Word, letter rune) int {
+	count := 0
+	length := word.Len()
+	for i := 0; i < length; i++ {
+		if word.At(i) == letter {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,322 @@
+package go_wordle_solver
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+// satisfyingWords returns the words that satisfy the restrictions.
+func satisfyingWords(restrictions *WordRestrictions, words ...string) []string {
+	var satisfying []string
+	for _, word := range words {
+		if restrictions.IsSatisfiedBy(WordFromString(word)) {
+			satisfying = append(satisfying, word)
+		}
+	}
+	return satisfying
+}
+
+func TestWordRestrictionsSetLetterAt(t *testing.T) {
+	restrictions := InitWordRestrictions(4)
+
+	assert.NilError(t, restrictions.SetLetterAt('s', 0))
+
+	assert.Equal(t, restrictions.State('s', 0), LetterRestrictionHere)
+	assert.Equal(t, restrictions.State('s', 1), LetterRestrictionPresentMaybeHere)
+	assert.DeepEqual(t, satisfyingWords(&restrictions, "sits", "tics", "sale"), []string{"sits", "sale"})
+}
+
+func TestWordRestrictionsSetLetterAtExcludesOtherLetters(t *testing.T) {
+	restrictions := InitWordRestrictions(4)
+	assert.NilError(t, restrictions.SetLetterMinCount('a', 1))
+
+	assert.NilError(t, restrictions.SetLetterAt('s', 0))
+
+	assert.Equal(t, restrictions.State('a', 0), LetterRestrictionPresentNotHere)
+	assert.ErrorContains(t, restrictions.SetLetterAt('a', 0), "already known to be there")
+}
+
+func TestWordRestrictionsSetLetterNotAt(t *testing.T) {
+	restrictions := InitWordRestrictions(4)
+
+	assert.NilError(t, restrictions.SetLetterNotAt('s', 0))
+
+	// The letter isn't known to be present.
+	assert.Equal(t, restrictions.State('s', 1), LetterRestrictionUnknown)
+	assert.DeepEqual(t, satisfyingWords(&restrictions, "sits", "tics", "tale"), []string{"tics", "tale"})
+
+	// Once the letter is present, the excluded location is kept.
+	assert.NilError(t, restrictions.SetLetterMinCount('s', 1))
+	assert.Equal(t, restrictions.State('s', 0), LetterRestrictionPresentNotHere)
+	assert.DeepEqual(t, satisfyingWords(&restrictions, "sits", "tics", "tale"), []string{"tics"})
+}
+
+func TestWordRestrictionsSetLetterNotAtEverywhere(t *testing.T) {
+	restrictions := InitWordRestrictions(3)
+
+	for location := uint8(0); location < 3; location++ {
+		assert.NilError(t, restrictions.SetLetterNotAt('s', location))
+	}
+
+	assert.Equal(t, restrictions.State('s', 0), LetterRestrictionNotPresent)
+}
+
+func TestWordRestrictionsSetLetterNotAtInfersLocation(t *testing.T) {
+	restrictions := InitWordRestrictions(3)
+	assert.NilError(t, restrictions.SetLetterMinCount('a', 1))
+
+	assert.NilError(t, restrictions.SetLetterNotAt('a', 0))
+	assert.NilError(t, restrictions.SetLetterNotAt('a', 1))
+
+	assert.Equal(t, restrictions.State('a', 2), LetterRestrictionHere)
+}
+
+func TestWordRestrictionsLetterCounts(t *testing.T) {
+	words := []string{"eel", "eye", "lee", "ell", "abc"}
+	restrictions := InitWordRestrictions(3)
+
+	assert.NilError(t, restrictions.SetLetterMaxCount('e', 1))
+	assert.DeepEqual(t, satisfyingWords(&restrictions, words...), []string{"ell", "abc"})
+
+	assert.NilError(t, restrictions.SetLetterMinCount('e', 1))
+	assert.DeepEqual(t, satisfyingWords(&restrictions, words...), []string{"ell"})
+	// The bounds are equal, so the exact count is known.
+	summary := restrictions.Summary()
+	assert.Equal(t, summary.PresentLetters[0].ExactCount.Value(), uint8(1))
+}
+
+func TestWordRestrictionsSetLetterCount(t *testing.T) {
+	restrictions := InitWordRestrictions(3)
+
+	assert.NilError(t, restrictions.SetLetterCount('e', 2))
+
+	assert.DeepEqual(t, satisfyingWords(&restrictions, "eel", "eye", "lee", "ell", "eee"), []string{"eel", "eye", "lee"})
+}
+
+func TestWordRestrictionsExcludeLetter(t *testing.T) {
+	restrictions := InitWordRestrictions(3)
+	assert.NilError(t, restrictions.SetLetterNotAt('e', 0))
+	assert.NilError(t, restrictions.SetLetterMaxCount('e', 1))
+
+	assert.NilError(t, restrictions.SetLetterMaxCount('e', 0))
+
+	assert.Equal(t, restrictions.State('e', 0), LetterRestrictionNotPresent)
+	// The other restrictions on this letter are now redundant.
+	assert.Equal(t, len(restrictions.Summary().OtherLetters), 0)
+	assert.DeepEqual(t, satisfyingWords(&restrictions, "eel", "abc"), []string{"abc"})
+}
+
+func TestWordRestrictionsSetNoRepeatedLetters(t *testing.T) {
+	restrictions := InitWordRestrictions(3)
+	assert.NilError(t, restrictions.SetLetterMinCount('e', 1))
+
+	assert.NilError(t, restrictions.SetNoRepeatedLetters())
+
+	assert.DeepEqual(t, satisfyingWords(&restrictions, "eel", "bee", "bet", "abc"), []string{"bet"})
+	summary := restrictions.Summary()
+	assert.Equal(t, summary.PresentLetters[0].ExactCount.Value(), uint8(1))
+	assert.ErrorContains(t, restrictions.SetLetterMinCount('e', 2), "at most 1 times")
+}
+
+func TestWordRestrictionsManualConstraintsApplyToUpdates(t *testing.T) {
+	restrictions := InitWordRestrictions(5)
+	assert.NilError(t, restrictions.SetNoRepeatedLetters())
+	assert.NilError(t, restrictions.SetLetterNotAt('r', 1))
+
+	result := GuessResult{
+		WordFromString("arise"),
+		[]LetterResult{LetterResultNotPresent, LetterResultPresentNotHere, LetterResultNotPresent, LetterResultNotPresent, LetterResultCorrect},
+	}
+	assert.NilError(t, restrictions.Update(&result))
+
+	// 'r' is now present, and keeps its excluded location.
+	assert.Equal(t, restrictions.State('r', 1), LetterRestrictionPresentNotHere)
+	assert.Equal(t, restrictions.State('r', 0), LetterRestrictionPresentMaybeHere)
+	summary := restrictions.Summary()
+	assert.Equal(t, summary.PresentLetters[1].ExactCount.Value(), uint8(1))
+
+	// Updates that contradict manual restrictions are rejected.
+	result = GuessResult{
+		WordFromString("roree"),
+		[]LetterResult{LetterResultCorrect, LetterResultNotPresent, LetterResultNotPresent, LetterResultNotPresent, LetterResultCorrect},
+	}
+	restrictions = InitWordRestrictions(5)
+	assert.NilError(t, restrictions.SetLetterMaxCount('e', 1))
+	assert.NilError(t, restrictions.SetLetterAt('e', 3))
+	assert.Assert(t, restrictions.Update(&result) != nil)
+}
+
+func TestWordRestrictionsManualConstraintContradictions(t *testing.T) {
+	tests := []struct {
+		name    string
+		setup   func(r *WordRestrictions) error
+		add     func(r *WordRestrictions) error
+		wantErr string
+	}{
+		{
+			"letter at out-of-range location",
+			func(r *WordRestrictions) error { return nil },
+			func(r *WordRestrictions) error { return r.SetLetterAt('a', 3) },
+			"out of range",
+		},
+		{
+			"excluded letter at location",
+			func(r *WordRestrictions) error { return r.ExcludeLetter('a') },
+			func(r *WordRestrictions) error { return r.SetLetterAt('a', 0) },
+			"not to be in the word",
+		},
+		{
+			"letter at location it's excluded from",
+			func(r *WordRestrictions) error { return r.SetLetterNotAt('a', 0) },
+			func(r *WordRestrictions) error { return r.SetLetterAt('a', 0) },
+			"already marked",
+		},
+		{
+			"letter not at its known location",
+			func(r *WordRestrictions) error { return r.SetLetterAt('a', 0) },
+			func(r *WordRestrictions) error { return r.SetLetterNotAt('a', 0) },
+			"already marked",
+		},
+		{
+			"excluding a present letter",
+			func(r *WordRestrictions) error { return r.SetLetterMinCount('a', 1) },
+			func(r *WordRestrictions) error { return r.ExcludeLetter('a') },
+			"already known to be in the word",
+		},
+		{
+			"min count above max count",
+			func(r *WordRestrictions) error { return r.SetLetterMaxCount('a', 1) },
+			func(r *WordRestrictions) error { return r.SetLetterMinCount('a', 2) },
+			"at most 1 times",
+		},
+		{
+			"max count below min count",
+			func(r *WordRestrictions) error { return r.SetLetterMinCount('a', 2) },
+			func(r *WordRestrictions) error { return r.SetLetterMaxCount('a', 1) },
+			"at least 2 times",
+		},
+		{
+			"min count above exact count",
+			func(r *WordRestrictions) error {
+				result, err := GetResultForGuess(WordFromString("abc"), WordFromString("aax"))
+				if err != nil {
+					return err
+				}
+				return r.Update(&result)
+			},
+			func(r *WordRestrictions) error { return r.SetLetterMinCount('a', 2) },
+			"exactly 1 times",
+		},
+		{
+			"no repeats with a repeated letter",
+			func(r *WordRestrictions) error { return r.SetLetterMinCount('a', 2) },
+			func(r *WordRestrictions) error { return r.SetNoRepeatedLetters() },
+			"at least 2 times",
+		},
+		{
+			"too many letters",
+			func(r *WordRestrictions) error { return r.SetLetterMinCount('a', 2) },
+			func(r *WordRestrictions) error { return r.SetLetterMinCount('b', 2) },
+			"at least 4 locations",
+		},
+		{
+			"min count with too few locations",
+			func(r *WordRestrictions) error { return r.SetLetterNotAt('a', 0) },
+			func(r *WordRestrictions) error { return r.SetLetterMinCount('a', 3) },
+			"only 2 possible locations",
+		},
+		{
+			"known locations leave no room",
+			func(r *WordRestrictions) error {
+				if err := r.SetLetterAt('a', 0); err != nil {
+					return err
+				}
+				return r.SetLetterAt('b', 1)
+			},
+			func(r *WordRestrictions) error { return r.SetLetterCount('c', 2) },
+			"at least 4 locations",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			restrictions := InitWordRestrictions(3)
+			assert.NilError(t, test.setup(&restrictions))
+			assert.ErrorContains(t, test.add(&restrictions), test.wantErr)
+		})
+	}
+}
+
+func TestRestrictionsSummaryWithManualConstraints(t *testing.T) {
+	restrictions := InitWordRestrictions(5)
+	assert.NilError(t, restrictions.SetLetterAt('s', 0))
+	assert.NilError(t, restrictions.SetLetterMaxCount('e', 2))
+	assert.NilError(t, restrictions.SetLetterMinCount('e', 1))
+	assert.NilError(t, restrictions.SetLetterNotAt('a', 1))
+	assert.NilError(t, restrictions.SetLetterNotAt('o', 4))
+	assert.NilError(t, restrictions.SetLetterMaxCount('o', 1))
+	assert.NilError(t, restrictions.ExcludeLetter('t'))
+
+	summary := restrictions.Summary()
+
+	assert.Equal(t, len(summary.OtherLetters), 2)
+	assert.DeepEqual(t, summary.OtherLetters[0].ExcludedLocations, []uint8{1})
+	assert.Assert(t, !summary.OtherLetters[0].MaxCount.HasValue())
+	assert.Equal(t, summary.OtherLetters[1].MaxCount.Value(), uint8(1))
+	assert.Equal(t, summary.String(), "S _ _ _ _ | one to two E, no A at pos 2, at most one O (not pos 5) | no T")
+
+	assert.NilError(t, restrictions.SetNoRepeatedLetters())
+	summary = restrictions.Summary()
+	assert.Equal(t, summary.String(), "S _ _ _ _ | exactly one E, exactly one S, no A at pos 2, no O at pos 5, no repeated letters | no T")
+}
+
+func TestWordRestrictionsMatcherWithManualConstraints(t *testing.T) {
+	bank, err := BuiltinWordBank("1000-wordle-shuffled")
+	assert.NilError(t, err)
+	words := bank.Words()
+	rng := rand.New(rand.NewSource(39))
+	letters := []rune("aeiorst")
+
+	for trial := 0; trial < 100; trial++ {
+		restrictions := InitWordRestrictions(bank.WordLength())
+		if rng.Intn(4) == 0 {
+			assert.NilError(t, restrictions.SetNoRepeatedLetters())
+		}
+		for i := 0; i < 3; i++ {
+			letter := letters[rng.Intn(len(letters))]
+			location := uint8(rng.Intn(int(bank.WordLength())))
+			count := uint8(rng.Intn(3))
+			// Ignore contradictions, as long as the restrictions are still usable.
+			switch rng.Intn(4) {
+			case 0:
+				restrictions.SetLetterAt(letter, location)
+			case 1:
+				restrictions.SetLetterNotAt(letter, location)
+			case 2:
+				restrictions.SetLetterMinCount(letter, count)
+			case 3:
+				restrictions.SetLetterMaxCount(letter, count)
+			}
+		}
+
+		matcher := restrictions.Matcher()
+		data, err := json.Marshal(&restrictions)
+		assert.NilError(t, err)
+		var fromJSON WordRestrictions
+		assert.NilError(t, json.Unmarshal(data, &fromJSON), "json: %s", data)
+		data, err = restrictions.MarshalBinary()
+		assert.NilError(t, err)
+		var fromBinary WordRestrictions
+		assert.NilError(t, fromBinary.UnmarshalBinary(data))
+		for j := 0; j < words.Len(); j++ {
+			word := words.At(j)
+			isSatisfied := restrictions.IsSatisfiedBy(word)
+			assert.Equal(t, matcher.Match(word), isSatisfied, "word: %s, summary: %s, matcher: %v", word, restrictions.Summary(), matcher)
+			assert.Equal(t, fromJSON.IsSatisfiedBy(word), isSatisfied, "word: %s, summary: %s", word, restrictions.Summary())
+			assert.Equal(t, fromBinary.IsSatisfiedBy(word), isSatisfied, "word: %s, summary: %s", word, restrictions.Summary())
+		}
+	}
+}
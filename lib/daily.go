@@ -0,0 +1,79 @@
+package go_wordle_solver
+
+import (
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"golang.org/x/exp/slices"
+)
+
+// WordleEpoch is the date of the first Wordle puzzle, which is puzzle number 0.
+var WordleEpoch = time.Date(2021, time.June, 19, 0, 0, 0, 0, time.UTC)
+
+// DailyPuzzles deterministically selects a "puzzle of the day" from a list of answers.
+//
+// Puzzles are numbered by the number of days since [WordleEpoch], and each puzzle's objective is
+// the answer at that index, wrapping around once all answers have been used.
+type DailyPuzzles struct {
+	answers []Word
+}
+
+// InitDailyPuzzles constructs a [DailyPuzzles] that selects answers in the order they appear in
+// the given bank.
+//
+// To match the original Wordle puzzles, the bank must contain the answers in their original order.
+func InitDailyPuzzles(answers *WordBank) DailyPuzzles {
+	return DailyPuzzles{slices.Clone(answers.allWords)}
+}
+
+// InitShuffledDailyPuzzles constructs a [DailyPuzzles] that selects answers from the given bank in
+// an order determined by the given seed.
+func InitShuffledDailyPuzzles(answers *WordBank, seed int64) DailyPuzzles {
+	shuffled := slices.Clone(answers.allWords)
+	rand.New(rand.NewSource(seed)).Shuffle(len(shuffled), func(i, j int) {
+		shuffled[i], shuffled[j] = shuffled[j], shuffled[i]
+	})
+	return DailyPuzzles{shuffled}
+}
+
+// Len returns the number of answers, i.e. the number of puzzles before answers repeat.
+func (d *DailyPuzzles) Len() int {
+	return len(d.answers)
+}
+
+// Number returns the number of the puzzle for the given date.
+//
+// Only the calendar date is used, in the date's own location. Returns an error if the date is
+// before [WordleEpoch].
+func (d *DailyPuzzles) Number(date time.Time) (int, error) {
+	year, month, day := date.Date()
+	days := int(time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Sub(WordleEpoch).Hours() / 24)
+	if days < 0 {
+		return 0, fmt.Errorf("The date %s is before the first puzzle on %s.", date.Format("2006-01-02"), WordleEpoch.Format("2006-01-02"))
+	}
+	return days, nil
+}
+
+// ForNumber returns the objective word for the given puzzle number.
+//
+// Returns an error if the number is negative, or if there are no answers.
+func (d *DailyPuzzles) ForNumber(number int) (Word, error) {
+	if number < 0 {
+		return Word{}, fmt.Errorf("Invalid puzzle number %v. Puzzles are numbered from 0.", number)
+	}
+	if len(d.answers) == 0 {
+		return Word{}, errors.New("There are no answers to pick puzzles from.")
+	}
+	return d.answers[number%len(d.answers)], nil
+}
+
+// ForDate returns the objective word for the given date. See [DailyPuzzles.Number].
+func (d *DailyPuzzles) ForDate(date time.Time) (Word, error) {
+	number, err := d.Number(date)
+	if err != nil {
+		return Word{}, err
+	}
+	return d.ForNumber(number)
+}
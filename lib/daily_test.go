@@ -0,0 +1,89 @@
+package go_wordle_solver
+
+import (
+	"testing"
+	"time"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestDailyPuzzlesNumber(t *testing.T) {
+	bank, err := WordBankFromSlice([]string{"abc", "def", "ghi"})
+	assert.NilError(t, err)
+	puzzles := InitDailyPuzzles(&bank)
+
+	number, err := puzzles.Number(WordleEpoch)
+	assert.NilError(t, err)
+	assert.Equal(t, number, 0)
+
+	// Wordle 500 was on 2022-11-01.
+	number, err = puzzles.Number(time.Date(2022, time.November, 1, 23, 59, 0, 0, time.UTC))
+	assert.NilError(t, err)
+	assert.Equal(t, number, 500)
+
+	// Only the calendar date matters, regardless of location.
+	location := time.FixedZone("UTC-10", -10*60*60)
+	number, err = puzzles.Number(time.Date(2022, time.November, 1, 23, 59, 0, 0, location))
+	assert.NilError(t, err)
+	assert.Equal(t, number, 500)
+
+	_, err = puzzles.Number(WordleEpoch.AddDate(0, 0, -1))
+	assert.ErrorContains(t, err, "before the first puzzle")
+}
+
+func TestDailyPuzzlesForDate(t *testing.T) {
+	bank, err := WordBankFromSlice([]string{"abc", "def", "ghi"})
+	assert.NilError(t, err)
+	puzzles := InitDailyPuzzles(&bank)
+	assert.Equal(t, puzzles.Len(), 3)
+
+	for i, want := range []string{"abc", "def", "ghi", "abc"} {
+		word, err := puzzles.ForDate(WordleEpoch.AddDate(0, 0, i))
+		assert.NilError(t, err)
+		assert.Equal(t, word.String(), want)
+	}
+}
+
+func TestDailyPuzzlesWordleAnswers(t *testing.T) {
+	bank, err := BuiltinWordBank("wordle-answers")
+	assert.NilError(t, err)
+	puzzles := InitDailyPuzzles(&bank)
+
+	word, err := puzzles.ForDate(WordleEpoch)
+	assert.NilError(t, err)
+	assert.Equal(t, word.String(), bank.allWords[0].String())
+}
+
+func TestShuffledDailyPuzzles(t *testing.T) {
+	bank, err := WordBankFromSlice([]string{"abc", "def", "ghi", "jkl", "mno", "pqr"})
+	assert.NilError(t, err)
+	puzzles := InitShuffledDailyPuzzles(&bank, 42)
+	again := InitShuffledDailyPuzzles(&bank, 42)
+	assert.Equal(t, puzzles.Len(), 6)
+
+	seen := make(map[string]bool)
+	for i := 0; i < puzzles.Len(); i++ {
+		word, err := puzzles.ForNumber(i)
+		assert.NilError(t, err)
+		againWord, err := again.ForNumber(i)
+		assert.NilError(t, err)
+		assert.Assert(t, word.Equal(againWord))
+		seen[word.String()] = true
+	}
+	assert.Equal(t, len(seen), 6)
+}
+
+func TestDailyPuzzlesForNumberErrors(t *testing.T) {
+	bank, err := WordBankFromSlice([]string{"abc", "def"})
+	assert.NilError(t, err)
+	puzzles := InitDailyPuzzles(&bank)
+
+	word, err := puzzles.ForNumber(3)
+	assert.NilError(t, err)
+	assert.Equal(t, word.String(), bank.allWords[1].String())
+	_, err = puzzles.ForNumber(-1)
+	assert.Error(t, err, "Invalid puzzle number -1. Puzzles are numbered from 0.")
+	empty := DailyPuzzles{}
+	_, err = empty.ForNumber(0)
+	assert.Error(t, err, "There are no answers to pick puzzles from.")
+}
@@ -0,0 +1,142 @@
+package go_wordle_solver
+
+import (
+	"fmt"
+	"strconv"
+
+	"golang.org/x/exp/slices"
+)
+
+// EquationSymbols contains every symbol that can appear in an equation from [Equations].
+const EquationSymbols = "0123456789+-*/="
+
+// The shortest and longest equations that [Equations] can generate. The shortest equations are of
+// the form "1+2=3".
+const (
+	MinEquationLength = 5
+	MaxEquationLength = 10
+)
+
+// The operators that can appear on the left side of an equation, in the order they're tried.
+const equationOperators = "+-*/"
+
+// Equations returns all valid Nerdle-style equations with the given number of symbols, in
+// increasing order.
+//
+// Each equation is a calculation on the left of a single '=', with its result on the right. The
+// rules follow Nerdle:
+//
+//   - The left side has at least one of the operators '+', '-', '*', and '/', and is evaluated
+//     with the usual order of operations, i.e. multiplication and division before addition and
+//     subtraction, and otherwise left to right.
+//   - Every division must be exact, so the calculation never leaves the integers.
+//   - The right side is a single number, which can't be negative.
+//   - Numbers never have leading zeros, and a zero can't be a number on its own, e.g. "0+12=12"
+//     and "12-12=0" are not valid.
+//   - Numbers can't be negated, e.g. "-1+3=2" is not valid.
+//
+// For example, there are 206 equations with 6 symbols, and 17080 with 8 symbols. The number grows
+// quickly with the length, so generating equations with 10 symbols takes several seconds.
+//
+// Returns an error if the length is not between [MinEquationLength] and [MaxEquationLength].
+func Equations(length int) ([]string, error) {
+	if length < MinEquationLength || length > MaxEquationLength {
+		return nil, fmt.Errorf("Equations must have between %v and %v symbols. Requested %v.", MinEquationLength, MaxEquationLength, length)
+	}
+	var equations []string
+	// The left side needs at least three symbols, and the right side at least one.
+	for leftLength := 3; leftLength <= length-2; leftLength++ {
+		rightLength := length - leftLength - 1
+		minResult, maxResult := numberRange(rightLength)
+		forEachCalculation(leftLength, func(left []byte, result int64) {
+			if result >= minResult && result <= maxResult {
+				equations = append(equations, string(left)+"="+strconv.FormatInt(result, 10))
+			}
+		})
+	}
+	slices.Sort(equations)
+	return equations, nil
+}
+
+// EquationWordBank constructs a [WordBank] of all the equations with the given length. See
+// [Equations].
+func EquationWordBank(length int) (WordBank, error) {
+	equations, err := Equations(length)
+	if err != nil {
+		return WordBank{}, err
+	}
+	return WordBankFromSlice(equations)
+}
+
+// numberRange returns the smallest and largest numbers with the given number of digits, excluding
+// zero.
+func numberRange(numDigits int) (int64, int64) {
+	max := int64(1)
+	for i := 0; i < numDigits; i++ {
+		max *= 10
+	}
+	return max / 10, max - 1
+}
+
+// forEachCalculation calls fn with each valid calculation with the given number of symbols, and
+// its result.
+//
+// The symbols passed to fn are only valid until fn returns.
+func forEachCalculation(length int, fn func(calculation []byte, result int64)) {
+	calculation := make([]byte, 0, length)
+	var appendNumber func(calculation []byte, operator byte, sum, term int64)
+	// appendNumber appends each possible number after the given operator, where sum is the total of
+	// all the finished terms, and term is the value of the current term so far.
+	appendNumber = func(calculation []byte, operator byte, sum, term int64) {
+		remaining := length - len(calculation)
+		for numDigits := 1; numDigits <= remaining; numDigits++ {
+			// Leave room for another operator and number, unless this is the last number.
+			if numDigits != remaining && numDigits > remaining-2 {
+				continue
+			}
+			// A single number isn't a calculation, so don't enumerate numbers that fill the space.
+			if operator == 0 && numDigits == remaining {
+				continue
+			}
+			min, max := numberRange(numDigits)
+			for number := min; number <= max; number++ {
+				nextSum, nextTerm, isValid := applyOperator(operator, sum, term, number)
+				if !isValid {
+					continue
+				}
+				next := strconv.AppendInt(calculation, number, 10)
+				if numDigits == remaining {
+					fn(next, nextSum+nextTerm)
+					continue
+				}
+				for i := 0; i < len(equationOperators); i++ {
+					appendNumber(append(next, equationOperators[i]), equationOperators[i], nextSum, nextTerm)
+				}
+			}
+		}
+	}
+	appendNumber(calculation, 0, 0, 0)
+}
+
+// applyOperator applies the operator to the given number, where sum is the total of all the
+// finished terms, and term is the value of the current term so far. An operator of 0 starts the
+// first term.
+//
+// Returns false if the result is not an integer.
+func applyOperator(operator byte, sum, term, number int64) (int64, int64, bool) {
+	switch operator {
+	case '+':
+		return sum + term, number, true
+	case '-':
+		return sum + term, -number, true
+	case '*':
+		return sum, term * number, true
+	case '/':
+		if term%number != 0 {
+			return 0, 0, false
+		}
+		return sum, term / number, true
+	default:
+		return 0, number, true
+	}
+}
@@ -0,0 +1,141 @@
+package go_wordle_solver
+
+import (
+	"strconv"
+	"strings"
+	"testing"
+
+	"golang.org/x/exp/slices"
+	"gotest.tools/v3/assert"
+)
+
+func TestEquations(t *testing.T) {
+	equations, err := Equations(5)
+
+	assert.NilError(t, err)
+	assert.Equal(t, len(equations), 118)
+	assert.Assert(t, slices.IsSorted(equations))
+	for _, want := range []string{"1+2=3", "9-1=8", "2*4=8", "8/2=4"} {
+		assert.Assert(t, slices.Contains(equations, want), want)
+	}
+	for _, notWant := range []string{"0+3=3", "3-3=0", "3/2=1", "2-5=3", "1+2=03"} {
+		assert.Assert(t, !slices.Contains(equations, notWant), notWant)
+	}
+}
+
+func TestEquationsCounts(t *testing.T) {
+	for _, test := range []struct{ length, want int }{{6, 206}, {7, 6277}, {8, 17080}} {
+		equations, err := Equations(test.length)
+
+		assert.NilError(t, err)
+		assert.Equal(t, len(equations), test.want, test.length)
+	}
+}
+
+func TestEquationsAreValid(t *testing.T) {
+	equations, err := Equations(8)
+	assert.NilError(t, err)
+
+	seen := make(map[string]bool, len(equations))
+	for _, equation := range equations {
+		assert.Assert(t, !seen[equation], equation)
+		seen[equation] = true
+		checkEquation(t, equation)
+	}
+	for _, want := range []string{"12+35=47", "3*4-2=10", "10-4/2=8", "99/9-8=3", "2+3*4=14"} {
+		assert.Assert(t, seen[want], want)
+	}
+	for _, notWant := range []string{"12+35=74", "6/4*2=3", "05+10=15", "10+0=10", "12-12=0"} {
+		assert.Assert(t, !seen[notWant], notWant)
+	}
+}
+
+// checkEquation independently checks that the equation is valid, evaluating multiplication and
+// division first.
+func checkEquation(t *testing.T, equation string) {
+	sides := strings.Split(equation, "=")
+	assert.Equal(t, len(sides), 2, equation)
+	right, err := strconv.Atoi(sides[1])
+	assert.NilError(t, err, equation)
+	assert.Assert(t, right > 0 && sides[1][0] != '0', equation)
+
+	left := sides[0]
+	assert.Assert(t, strings.ContainsAny(left, equationOperators), equation)
+	sum := 0
+	for _, term := range splitTerms(left) {
+		factors := strings.FieldsFunc(term[1:], func(r rune) bool { return r == '*' || r == '/' })
+		ops := strings.Map(func(r rune) rune {
+			if r == '*' || r == '/' {
+				return r
+			}
+			return -1
+		}, term[1:])
+		value := parseEquationNumber(t, equation, factors[0])
+		for i, op := range ops {
+			factor := parseEquationNumber(t, equation, factors[i+1])
+			if op == '*' {
+				value *= factor
+			} else {
+				assert.Equal(t, value%factor, 0, equation)
+				value /= factor
+			}
+		}
+		if term[0] == '-' {
+			value = -value
+		}
+		sum += value
+	}
+	assert.Equal(t, sum, right, equation)
+}
+
+// splitTerms splits the calculation into terms that each start with their sign.
+func splitTerms(calculation string) []string {
+	var terms []string
+	start := 0
+	for i := 1; i < len(calculation); i++ {
+		if calculation[i] == '+' || calculation[i] == '-' {
+			terms = append(terms, "+"+calculation[start:i])
+			start = i
+		}
+	}
+	terms = append(terms, "+"+calculation[start:])
+	for i, term := range terms {
+		if term[1] == '+' || term[1] == '-' {
+			terms[i] = term[1:]
+		}
+	}
+	return terms
+}
+
+func parseEquationNumber(t *testing.T, equation, number string) int {
+	assert.Assert(t, len(number) > 0 && number[0] != '0', equation)
+	value, err := strconv.Atoi(number)
+	assert.NilError(t, err, equation)
+	return value
+}
+
+func TestEquationsInvalidLength(t *testing.T) {
+	_, err := Equations(4)
+	assert.Error(t, err, "Equations must have between 5 and 10 symbols. Requested 4.")
+	_, err = Equations(11)
+	assert.Error(t, err, "Equations must have between 5 and 10 symbols. Requested 11.")
+}
+
+func TestPlayEquationGame(t *testing.T) {
+	bank, err := EquationWordBank(6)
+	assert.NilError(t, err)
+	scorer, err := InitMaxEliminationsScorer(&bank)
+	assert.NilError(t, err)
+	guesser := InitMaxScoreGuesser(&bank, &scorer, GuessModeAll)
+	words := bank.Words()
+
+	for i := 0; i < words.Len(); i += 10 {
+		objective := words.At(i)
+
+		result, err := PlayGameWithGuesser(objective, 10, &guesser)
+
+		assert.NilError(t, err)
+		assert.Equal(t, result.Status, GameSuccess, objective)
+		assert.Assert(t, len(result.Turns) <= 5, objective)
+	}
+}
@@ -0,0 +1,278 @@
+package go_wordle_solver
+
+import (
+	"encoding/binary"
+	"fmt"
+	"math"
+
+	"golang.org/x/exp/slices"
+)
+
+// This file provides exact searches for the best guess when only a few words are possible.
+
+// The number of guesses that aren't possible words that exact searches consider. The guesses that
+// split the possible words into the most groups are chosen.
+const numExtraGuessesForExactSearch = 30
+
+// DefaultEndgameThreshold is the default number of possible words at or below which
+// [MaxScoreGuesser] searches exactly for the best guess. See
+// [MaxScoreGuesser.SetEndgameThreshold].
+const DefaultEndgameThreshold = 30
+
+// resultTable caches the result of each guess for each possible word, for use in exact searches.
+type resultTable struct {
+	// The guesses to choose from. The possible words come first, in the same order.
+	guesses PossibleWords
+	// The index of each guess in the possible words, or -1 if it isn't a possible word.
+	possibleIndex []int32
+	// The compressed result for each guess, then for each possible word.
+	results [][]CompressedGuessResult64
+}
+
+// canSearchExactly returns true iff the results for the given words can be stored in a
+// [resultTable], i.e. the words have at most [MaxLettersInCompressedGuessResult64] letters.
+func canSearchExactly(possibleWords *PossibleWords) bool {
+	return possibleWords.restrictions.wordLength <= MaxLettersInCompressedGuessResult64
+}
+
+// initResultTable builds a table for the possible words, plus the unguessed words that split them
+// into the most groups if the mode allows guessing any word.
+//
+// The words must have at most [MaxLettersInCompressedGuessResult64] letters. See
+// [canSearchExactly].
+func initResultTable(possibleWords, unguessedWords *PossibleWords, mode GuessMode) resultTable {
+	guesses := slices.Clone(possibleWords.words)
+	if mode == GuessModeAll {
+		type scoredGuess struct {
+			word    Word
+			numWins int
+		}
+		var extra []scoredGuess
+		for i := 0; i < unguessedWords.Len(); i++ {
+			word := unguessedWords.At(i)
+			if slices.IndexFunc(possibleWords.words, word.Equal) >= 0 {
+				continue
+			}
+			extra = append(extra, scoredGuess{word, numWinsWithinTwo(word, possibleWords)})
+		}
+		slices.SortStableFunc(extra, func(a, b scoredGuess) bool {
+			return a.numWins > b.numWins
+		})
+		for i := 0; i < len(extra) && i < numExtraGuessesForExactSearch; i++ {
+			guesses = append(guesses, extra[i].word)
+		}
+	}
+
+	rule := possibleWords.FeedbackRule()
+	numPossible := possibleWords.Len()
+	table := resultTable{
+		guesses:       initPossibleWords(guesses, rule),
+		possibleIndex: make([]int32, len(guesses)),
+		results:       make([][]CompressedGuessResult64, len(guesses)),
+	}
+	for g, guess := range guesses {
+		table.possibleIndex[g] = -1
+		if g < numPossible {
+			table.possibleIndex[g] = int32(g)
+		}
+		table.results[g] = make([]CompressedGuessResult64, numPossible)
+		for w := 0; w < numPossible; w++ {
+			result, err := rule.Feedback(possibleWords.At(w), guess)
+			if err == nil {
+				table.results[g][w], err = CompressResults64(result.Results)
+			}
+			if err != nil {
+				panic(fmt.Sprintf("Failed to compute the result for guess: %s, error: %s", guess, err))
+			}
+		}
+	}
+	return table
+}
+
+// groupByResult groups the given possible words by the result they give for the guess.
+//
+// The guess itself is excluded from the groups, and instead this returns true if it was one of the
+// words. Each group stays in the same order as words.
+func (self *resultTable) groupByResult(guess int, words []int32) (bool, map[CompressedGuessResult64][]int32) {
+	isPossible := false
+	groups := make(map[CompressedGuessResult64][]int32)
+	for _, w := range words {
+		if w == self.possibleIndex[guess] {
+			isPossible = true
+			continue
+		}
+		result := self.results[guess][w]
+		groups[result] = append(groups[result], w)
+	}
+	return isPossible, groups
+}
+
+// allPossibleIndices returns the indices of all the possible words in a [resultTable].
+func allPossibleIndices(numPossible int) []int32 {
+	words := make([]int32, numPossible)
+	for i := range words {
+		words[i] = int32(i)
+	}
+	return words
+}
+
+// memoKey returns a key for the given sorted set of words, and any other non-negative state in
+// extra.
+//
+// Each value is encoded as a varint, so that any number of words can be used.
+func memoKey(words []int32, extra int) string {
+	key := make([]byte, binary.MaxVarintLen64+len(words)*binary.MaxVarintLen32)
+	n := binary.PutUvarint(key, uint64(extra))
+	for _, w := range words {
+		n += binary.PutUvarint(key[n:], uint64(w))
+	}
+	return string(key[:n])
+}
+
+// numWinsWithinTwo returns the number of possible words that can be solved within two guesses, if
+// the given word is guessed first.
+//
+// This is one if the guess is a possible word, plus the number of distinct incorrect results it
+// gives, since each of those results leaves one chance to guess the word.
+func numWinsWithinTwo(guess Word, possibleWords *PossibleWords) int {
+	rule := possibleWords.FeedbackRule()
+	seen := make(map[CompressedGuessResult64]bool)
+	numWins := 0
+	for i := 0; i < possibleWords.Len(); i++ {
+		objective := possibleWords.At(i)
+		if objective.Equal(guess) {
+			numWins++
+			continue
+		}
+		result, err := rule.Feedback(objective, guess)
+		if err != nil {
+			continue
+		}
+		compressed, err := CompressResults64(result.Results)
+		if err != nil || seen[compressed] {
+			continue
+		}
+		seen[compressed] = true
+		numWins++
+	}
+	return numWins
+}
+
+// selectBestGuess returns the guess with the highest value, preferring possible words and then the
+// highest score when there are ties.
+func selectBestGuess(possibleWords, guesses *PossibleWords, score func(Word) int64, value func(guess int) int) Word {
+	possible := make(map[string]bool, possibleWords.Len())
+	for i := 0; i < possibleWords.Len(); i++ {
+		possible[possibleWords.At(i).String()] = true
+	}
+	var bestWord Word
+	bestValue := math.MinInt
+	bestIsPossible := false
+	for i := 0; i < guesses.Len(); i++ {
+		word := guesses.At(i)
+		value := value(i)
+		if value < bestValue {
+			continue
+		}
+		isPossible := possible[word.String()]
+		if value == bestValue {
+			if bestIsPossible && !isPossible {
+				continue
+			}
+			if bestIsPossible == isPossible && score(word) <= score(bestWord) {
+				continue
+			}
+		}
+		bestWord, bestValue, bestIsPossible = word, value, isPossible
+	}
+	return bestWord
+}
+
+// endgameSearch searches exactly for the guesses that minimize the total number of guesses needed
+// to solve each of a small set of possible words.
+type endgameSearch struct {
+	resultTable
+	guessMode GuessMode
+	// The fewest total guesses for sets of possible words.
+	memo map[string]int
+}
+
+// selectEndgameGuess returns the guess that minimizes the expected number of guesses needed to
+// solve the puzzle, assuming each possible word is equally likely.
+//
+// Ties are broken in favour of possible words, and then by the score.
+func selectEndgameGuess(possibleWords, unguessedWords *PossibleWords, mode GuessMode, score func(Word) int64) Word {
+	search := endgameSearch{
+		initResultTable(possibleWords, unguessedWords, mode),
+		mode,
+		make(map[string]int),
+	}
+	words := allPossibleIndices(possibleWords.Len())
+	return selectBestGuess(possibleWords, &search.guesses, score, func(guess int) int {
+		return -search.totalGuessesAfterGuess(guess, words, math.MaxInt)
+	})
+}
+
+// totalGuesses returns the fewest guesses needed to solve each of the given words, summed over the
+// words.
+func (self *endgameSearch) totalGuesses(words []int32) int {
+	switch len(words) {
+	case 0:
+		return 0
+	case 1:
+		return 1
+	case 2:
+		// Guess one, and then the other if needed.
+		return 3
+	}
+	key := memoKey(words, 0)
+	if total, isPresent := self.memo[key]; isPresent {
+		return total
+	}
+	// At best, one word is guessed first, and every other word is guessed second.
+	lowerBound := 2*len(words) - 1
+	best := math.MaxInt
+	if self.guessMode == GuessModePossible {
+		// The possible words come first in the table, so their indices are also guess indices.
+		for _, w := range words {
+			best = minInt(best, self.totalGuessesAfterGuess(int(w), words, best))
+			if best == lowerBound {
+				break
+			}
+		}
+	} else {
+		for g := range self.guesses.words {
+			best = minInt(best, self.totalGuessesAfterGuess(g, words, best))
+			if best == lowerBound {
+				break
+			}
+		}
+	}
+	self.memo[key] = best
+	return best
+}
+
+// totalGuessesAfterGuess returns the fewest guesses needed to solve each of the given words, summed
+// over the words, if the given guess is made first.
+//
+// If this can't be fewer than limit, this may stop early and return any value that's at least
+// limit.
+func (self *endgameSearch) totalGuessesAfterGuess(guess int, words []int32, limit int) int {
+	isPossible, groups := self.groupByResult(guess, words)
+	// A guess that doesn't split the words is never useful.
+	if !isPossible && len(groups) == 1 {
+		return math.MaxInt
+	}
+	// Every word needs this guess, and each group needs at least as much as its lower bound.
+	total := len(words)
+	for _, group := range groups {
+		total += 2*len(group) - 1
+	}
+	for _, group := range groups {
+		if total >= limit {
+			return total
+		}
+		total += self.totalGuesses(group) - (2*len(group) - 1)
+	}
+	return total
+}
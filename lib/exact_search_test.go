@@ -0,0 +1,185 @@
+package go_wordle_solver
+
+import (
+	"math"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestNumWinsWithinTwo(t *testing.T) {
+	bank, err := WordBankFromSlice([]string{"abc", "abd", "abe", "xyz"})
+	assert.NilError(t, err)
+	words := bank.Words()
+
+	// abc wins itself, and gives "gg-" for both abd and abe, and "---" for xyz.
+	assert.Equal(t, numWinsWithinTwo(WordFromString("abc"), &words), 3)
+	// xyz wins itself, and gives "---" for the rest.
+	assert.Equal(t, numWinsWithinTwo(WordFromString("xyz"), &words), 2)
+}
+
+func TestResultTable(t *testing.T) {
+	bank, err := WordBankFromSlice([]string{"bat", "cat", "hat", "mat", "chm", "xyz"})
+	assert.NilError(t, err)
+	all := bank.Words()
+	possible := bank.Words()
+	result := guessResult(t, "zat", "-gg")
+	assert.NilError(t, possible.Filter(&result))
+
+	table := initResultTable(&possible, &all, GuessModeAll)
+
+	// The possible words come first, then the other words in order of how well they split them.
+	var guesses []string
+	for i := 0; i < table.guesses.Len(); i++ {
+		guesses = append(guesses, table.guesses.At(i).String())
+	}
+	assert.DeepEqual(t, guesses, []string{"bat", "cat", "hat", "mat", "chm", "xyz"})
+	assert.DeepEqual(t, table.possibleIndex, []int32{0, 1, 2, 3, -1, -1})
+	isPossible, groups := table.groupByResult(0, allPossibleIndices(4))
+	assert.Assert(t, isPossible)
+	assert.Equal(t, len(groups), 1)
+	isPossible, groups = table.groupByResult(4, allPossibleIndices(4))
+	assert.Assert(t, !isPossible)
+	assert.Equal(t, len(groups), 4)
+
+	possibleOnly := initResultTable(&possible, &all, GuessModePossible)
+	assert.Equal(t, possibleOnly.guesses.Len(), 4)
+}
+
+func TestMemoKey(t *testing.T) {
+	assert.Assert(t, memoKey([]int32{1, 2}, 0) != memoKey([]int32{258}, 0))
+	assert.Assert(t, memoKey([]int32{1, 2}, 0) != memoKey([]int32{1, 2}, 1))
+	assert.Equal(t, memoKey([]int32{1, 2}, 3), memoKey([]int32{1, 2}, 3))
+	// Indices that don't fit in 16 bits are still distinct.
+	assert.Assert(t, memoKey([]int32{1}, 0) != memoKey([]int32{65537}, 0))
+	assert.Assert(t, memoKey([]int32{1, 1}, 0) != memoKey([]int32{65537}, 0))
+}
+
+func TestEndgameSearchMatchesBruteForce(t *testing.T) {
+	var words []string
+	for _, w := range allWords("abcd", 3) {
+		if len(words) < 12 && w.At(0) != w.At(1) {
+			words = append(words, w.String())
+		}
+	}
+	bank, err := WordBankFromSlice(append(words, "dca", "bdc"))
+	assert.NilError(t, err)
+	all := bank.Words()
+	possible := initPossibleWords(all.words[:len(words)], WordleRule{})
+
+	for _, mode := range []GuessMode{GuessModeAll, GuessModePossible} {
+		search := endgameSearch{initResultTable(&possible, &all, mode), mode, make(map[string]int)}
+		indices := allPossibleIndices(len(words))
+
+		assert.Equal(t, search.totalGuesses(indices), bruteForceTotalGuesses(&search, indices), mode)
+	}
+}
+
+// bruteForceTotalGuesses tries every allowed guess at every level, without any pruning.
+func bruteForceTotalGuesses(search *endgameSearch, words []int32) int {
+	if len(words) == 0 {
+		return 0
+	}
+	best := math.MaxInt
+	for g := range search.guesses.words {
+		if search.guessMode == GuessModePossible && !containsIndex(words, search.possibleIndex[g]) {
+			continue
+		}
+		isPossible, groups := search.groupByResult(g, words)
+		if !isPossible && len(groups) == 1 {
+			continue
+		}
+		total := len(words)
+		for _, group := range groups {
+			total += bruteForceTotalGuesses(search, group)
+		}
+		best = minInt(best, total)
+	}
+	return best
+}
+
+func containsIndex(words []int32, index int32) bool {
+	for _, w := range words {
+		if w == index {
+			return true
+		}
+	}
+	return false
+}
+
+func TestMaxScoreGuesserEndgame(t *testing.T) {
+	bank, err := WordBankFromSlice([]string{"bat", "cat", "hat", "mat", "chm", "xyz"})
+	assert.NilError(t, err)
+	scorer, err := InitMaxEliminationsScorer(&bank)
+	assert.NilError(t, err)
+	result := guessResult(t, "zat", "-gg")
+
+	for _, test := range []struct {
+		mode GuessMode
+		want string
+	}{
+		// Guessing chm splits all four words, so each is solved within two guesses.
+		{GuessModeAll, "chm"},
+		{GuessModePossible, "bat"},
+	} {
+		guesser := InitMaxScoreGuesser(&bank, &scorer, test.mode)
+		assert.NilError(t, guesser.Update(&result))
+
+		guess := guesser.SelectNextGuess()
+
+		assert.Equal(t, guess.Value().String(), test.want, test.mode)
+	}
+}
+
+func TestMaxScoreGuesserEndgameImprovesAverage(t *testing.T) {
+	bank := firstWordsBank(t, 300)
+	scorer, err := InitMaxEliminationsScorer(&bank)
+	assert.NilError(t, err)
+	words := bank.Words()
+
+	for _, mode := range []GuessMode{GuessModeAll, GuessModePossible} {
+		totalGuesses := func(threshold int) int {
+			guesser := InitMaxScoreGuesser(&bank, &scorer, mode)
+			guesser.SetEndgameThreshold(threshold)
+			total := 0
+			for i := 0; i < words.Len(); i++ {
+				result, err := PlayGameWithGuesser(words.At(i), 20, &guesser)
+				assert.NilError(t, err)
+				assert.Equal(t, result.Status, GameSuccess)
+				total += len(result.Turns)
+			}
+			return total
+		}
+
+		greedy := totalGuesses(0)
+		endgame := totalGuesses(DefaultEndgameThreshold)
+		assert.Assert(t, endgame <= greedy, "%s: %v vs %v", mode, endgame, greedy)
+		if mode == GuessModeAll {
+			assert.Assert(t, endgame < greedy, "%s: %v vs %v", mode, endgame, greedy)
+		}
+	}
+}
+
+func TestEndgameWithLongWords(t *testing.T) {
+	for _, length := range []uint8{MaxLettersInCompressedGuessResult + 1, MaxLettersInCompressedGuessResult64 + 1} {
+		prefix := strings.Repeat("a", int(length)-2)
+		bank, err := WordBankFromSlice([]string{
+			prefix + "bc", prefix + "bd", prefix + "be", prefix + "cb", prefix + "db", prefix + "eb",
+		})
+		assert.NilError(t, err)
+		words := bank.Words()
+		scorer := InitLocatedLettersScorer(&bank)
+		maxScore := InitMaxScoreGuesser(&bank, &scorer, GuessModeAll)
+		winProbability := InitWinProbabilityGuesser(&bank, &scorer, GuessModeAll)
+
+		for i := 0; i < words.Len(); i++ {
+			result, err := PlayGameWithGuesser(words.At(i), 10, &maxScore)
+			assert.NilError(t, err)
+			assert.Equal(t, result.Status, GameSuccess, length)
+			result, err = PlayGameWithGuesser(words.At(i), 10, &winProbability)
+			assert.NilError(t, err)
+			assert.Equal(t, result.Status, GameSuccess, length)
+		}
+	}
+}
@@ -0,0 +1,116 @@
+package go_wordle_solver
+
+import "fmt"
+
+// FeedbackRule determines the feedback that is given for each guess.
+//
+// Feedback is always given as a [GuessResult] with one [LetterResult] per letter, so that it can be
+// compressed and counted in the same way for every rule. Rules that only give counts, such as
+// [JottoRule] and [MastermindRule], put their counts in a canonical order that doesn't depend on
+// where the letters are in the guess.
+//
+// The rule for a game is set on the [WordBank] with [WordBank.WithFeedbackRule]. The
+// [PossibleWords] from that bank then filter with the rule, so scorers, guessers, and
+// [PlayGameWithGuesser] all work unchanged with it.
+type FeedbackRule interface {
+	// Feedback determines the feedback for the given guess when applied to the given objective.
+	//
+	// This must return an error if the words are not the same length.
+	Feedback(objective, guess Word) (GuessResult, error)
+}
+
+// WordleRule gives the standard Wordle feedback for each letter, as computed by
+// [GetResultForGuess].
+type WordleRule struct{}
+
+// Feedback determines the Wordle result of the guess. See [GetResultForGuess].
+func (WordleRule) Feedback(objective, guess Word) (GuessResult, error) {
+	return GetResultForGuess(objective, guess)
+}
+
+// JottoRule only gives the number of letters that the guess and objective have in common.
+//
+// Repeated letters are counted as many times as they appear in both words. The count is given as
+// that many [LetterResultPresentNotHere] results at the start of the result, with the rest
+// [LetterResultNotPresent]. For example, guessing "sassy" for "mesas" gives "yyy--".
+type JottoRule struct{}
+
+// Feedback determines the number of letters in common between the guess and objective.
+func (JottoRule) Feedback(objective, guess Word) (GuessResult, error) {
+	result, err := GetResultForGuess(objective, guess)
+	if err != nil {
+		return GuessResult{}, err
+	}
+	numCorrect, numPresent := countLetterResults(result.Results)
+	return countsResult(guess, 0, numCorrect+numPresent), nil
+}
+
+// MastermindRule gives the number of letters that are in the right location, and the number that
+// are present elsewhere, without saying which letters they are.
+//
+// The counts are given as that many [LetterResultCorrect] results at the start of the result,
+// followed by that many [LetterResultPresentNotHere] results, with the rest
+// [LetterResultNotPresent]. For example, guessing "sassy" for "mesas" gives "gyy--".
+type MastermindRule struct{}
+
+// Feedback determines the number of letters that are in the right location, and the number that
+// are present elsewhere.
+func (MastermindRule) Feedback(objective, guess Word) (GuessResult, error) {
+	result, err := GetResultForGuess(objective, guess)
+	if err != nil {
+		return GuessResult{}, err
+	}
+	numCorrect, numPresent := countLetterResults(result.Results)
+	return countsResult(guess, numCorrect, numPresent), nil
+}
+
+// FeedbackRuleFromName returns the built-in rule with the given name: "wordle", "jotto", or
+// "mastermind".
+func FeedbackRuleFromName(name string) (FeedbackRule, error) {
+	switch name {
+	case "wordle":
+		return WordleRule{}, nil
+	case "jotto":
+		return JottoRule{}, nil
+	case "mastermind":
+		return MastermindRule{}, nil
+	default:
+		return nil, fmt.Errorf("Unknown feedback rule %q. Expected one of %v.", name, FeedbackRuleNames())
+	}
+}
+
+// FeedbackRuleNames returns the names of the built-in feedback rules.
+func FeedbackRuleNames() []string {
+	return []string{"wordle", "jotto", "mastermind"}
+}
+
+// countLetterResults returns the number of results that are correct, and the number that are
+// present but not here.
+func countLetterResults(results []LetterResult) (numCorrect, numPresent int) {
+	for _, lr := range results {
+		switch lr {
+		case LetterResultCorrect:
+			numCorrect++
+		case LetterResultPresentNotHere:
+			numPresent++
+		}
+	}
+	return numCorrect, numPresent
+}
+
+// countsResult returns a result for the guess with numCorrect correct results, followed by
+// numPresent present results, with the rest not present.
+func countsResult(guess Word, numCorrect, numPresent int) GuessResult {
+	results := make([]LetterResult, guess.Len())
+	for i := range results {
+		switch {
+		case i < numCorrect:
+			results[i] = LetterResultCorrect
+		case i < numCorrect+numPresent:
+			results[i] = LetterResultPresentNotHere
+		default:
+			results[i] = LetterResultNotPresent
+		}
+	}
+	return GuessResult{Guess: guess, Results: results}
+}
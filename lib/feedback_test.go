@@ -0,0 +1,163 @@
+package go_wordle_solver
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestFeedbackRules(t *testing.T) {
+	tests := []struct {
+		rule      FeedbackRule
+		objective string
+		guess     string
+		want      string
+	}{
+		{WordleRule{}, "mesas", "sassy", "yyg--"},
+		{JottoRule{}, "mesas", "sassy", "yyy--"},
+		{MastermindRule{}, "mesas", "sassy", "gyy--"},
+		{JottoRule{}, "abcde", "edcba", "yyyyy"},
+		{MastermindRule{}, "abcde", "edcba", "gyyyy"},
+		{JottoRule{}, "abcde", "fghij", "-----"},
+		{MastermindRule{}, "abcde", "abcde", "ggggg"},
+	}
+	for _, test := range tests {
+		result, err := test.rule.Feedback(WordFromString(test.objective), WordFromString(test.guess))
+
+		assert.NilError(t, err)
+		assert.Equal(t, result.Guess.String(), test.guess)
+		assert.Equal(t, LetterResultsString(result.Results), test.want, "%T: %s for %s", test.rule, test.guess, test.objective)
+	}
+}
+
+func TestFeedbackRulesWrongLength(t *testing.T) {
+	for _, rule := range []FeedbackRule{WordleRule{}, JottoRule{}, MastermindRule{}} {
+		_, err := rule.Feedback(WordFromString("abc"), WordFromString("abcd"))
+
+		assert.Error(t, err, "The guess (abcd) must be the same length as the objective (length: 3).")
+	}
+}
+
+func TestFeedbackRuleFromName(t *testing.T) {
+	for _, name := range FeedbackRuleNames() {
+		_, err := FeedbackRuleFromName(name)
+		assert.NilError(t, err)
+	}
+	rule, err := FeedbackRuleFromName("jotto")
+	assert.NilError(t, err)
+	assert.Equal(t, rule, FeedbackRule(JottoRule{}))
+
+	_, err = FeedbackRuleFromName("bulls")
+	assert.Error(t, err, `Unknown feedback rule "bulls". Expected one of [wordle jotto mastermind].`)
+}
+
+func TestWordBankWithFeedbackRule(t *testing.T) {
+	bank, err := WordBankFromSlice([]string{"abc", "bca", "def"})
+	assert.NilError(t, err)
+
+	jottoBank := bank.WithFeedbackRule(JottoRule{})
+
+	assert.Equal(t, bank.FeedbackRule(), FeedbackRule(WordleRule{}))
+	assert.Equal(t, jottoBank.FeedbackRule(), FeedbackRule(JottoRule{}))
+	words := jottoBank.Words()
+	assert.Equal(t, words.FeedbackRule(), FeedbackRule(JottoRule{}))
+	copied := words.Copy()
+	assert.Equal(t, copied.FeedbackRule(), FeedbackRule(JottoRule{}))
+}
+
+func TestPossibleWordsFilterWithFeedbackRules(t *testing.T) {
+	for _, rule := range []FeedbackRule{JottoRule{}, MastermindRule{}} {
+		words := initPossibleWords(allWords("abcde", 4), rule)
+		objective := WordFromString("cabd")
+		var results []GuessResult
+
+		for _, guess := range []string{"aabb", "ccde", "abcd"} {
+			result, err := rule.Feedback(objective, WordFromString(guess))
+			assert.NilError(t, err)
+			results = append(results, result)
+
+			assert.NilError(t, words.Filter(&result))
+
+			numWant := 0
+			for _, w := range allWords("abcde", 4) {
+				if !hasBeenGuessed(w, results) && hasSameRuleFeedback(t, rule, w, results) {
+					numWant++
+				}
+			}
+			assert.Equal(t, words.Len(), numWant, "%T", rule)
+			for i := 0; i < words.Len(); i++ {
+				assert.Assert(t, hasSameRuleFeedback(t, rule, words.At(i), results))
+			}
+		}
+	}
+}
+
+func TestPossibleWordsFilterWithFeedbackRuleAndNoMatches(t *testing.T) {
+	bank, err := WordBankFromSlice([]string{"abc", "bca", "def"})
+	assert.NilError(t, err)
+	jottoBank := bank.WithFeedbackRule(JottoRule{})
+	words := jottoBank.Words()
+	result := guessResult(t, "abc", "yy-")
+
+	err = words.Filter(&result)
+
+	assert.Error(t, err, "No words would give the result yy- for abc.")
+	assert.Equal(t, words.Len(), 3)
+}
+
+func hasBeenGuessed(w Word, results []GuessResult) bool {
+	for _, result := range results {
+		if result.Guess.Equal(w) {
+			return true
+		}
+	}
+	return false
+}
+
+func hasSameRuleFeedback(t *testing.T, rule FeedbackRule, objective Word, results []GuessResult) bool {
+	for _, result := range results {
+		feedback, err := rule.Feedback(objective, result.Guess)
+		assert.NilError(t, err)
+		if LetterResultsString(feedback.Results) != LetterResultsString(result.Results) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestPossibleWordsFilterWithFeedbackRuleWrongLength(t *testing.T) {
+	words := initPossibleWords([]Word{WordFromString("abc"), WordFromString("def")}, JottoRule{})
+	result := guessResult(t, "abcd", "yy--")
+
+	assert.Error(t, words.Filter(&result), "The guess (abcd) and results must have 3 letters.")
+	assert.Equal(t, words.Len(), 2)
+}
+
+func TestPlayGameWithFeedbackRules(t *testing.T) {
+	full, err := BuiltinWordBank("1000-improved-shuffled")
+	assert.NilError(t, err)
+	words := full.Words()
+	bankWords := make([]string, 200)
+	for i := range bankWords {
+		bankWords[i] = words.At(i).String()
+	}
+	// Add anagrams so that Jotto games can't be won by the feedback alone.
+	bankWords = append(bankWords, "least", "slate", "stale", "steal")
+	bank, err := WordBankFromSlice(bankWords)
+	assert.NilError(t, err)
+
+	for _, rule := range []FeedbackRule{JottoRule{}, MastermindRule{}} {
+		ruleBank := bank.WithFeedbackRule(rule)
+		scorer, err := InitMaxEliminationsScorer(&ruleBank)
+		assert.NilError(t, err)
+		guesser := InitMaxScoreGuesser(&ruleBank, &scorer, GuessModeAll)
+
+		for _, objective := range []string{bankWords[0], bankWords[100], "slate", "steal"} {
+			result, err := PlayGameWithGuesser(WordFromString(objective), 20, &guesser)
+
+			assert.NilError(t, err)
+			assert.Equal(t, result.Status, GameSuccess, "%T: %s", rule, objective)
+			assert.Equal(t, result.Turns[len(result.Turns)-1].Guess.String(), objective)
+		}
+	}
+}
@@ -0,0 +1,211 @@
+package go_wordle_solver
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestFibbleWordsUpdate(t *testing.T) {
+	bank, err := WordBankFromSlice([]string{"abc", "abd", "bcd", "cde"})
+	assert.NilError(t, err)
+	words, err := InitFibbleWords(&bank, 1)
+	assert.NilError(t, err)
+	// The true result for abd is "gg-", so "ggy" has one lie. For bcd it's "yy-", and for cde it's
+	// "--y".
+	result := guessResult(t, "abc", "ggy")
+
+	assert.NilError(t, words.Update(&result))
+
+	assert.Equal(t, words.NumResults(), 1)
+	// The guess itself is never consistent.
+	assert.Equal(t, words.NumConsistentResults(0), 0)
+	assert.Equal(t, words.NumConsistentResults(1), 1)
+	assert.Equal(t, words.NumConsistentResults(2), 0)
+	assert.Equal(t, words.NumConsistentResults(3), 0)
+	possible := words.PossibleWords()
+	assert.Equal(t, possible.Len(), 1)
+	assert.Equal(t, possible.At(0).String(), "abd")
+}
+
+func TestFibbleWordsUpdateCountsConsistentResults(t *testing.T) {
+	bank, err := WordBankFromSlice([]string{"abc", "abd", "bcd", "cde"})
+	assert.NilError(t, err)
+	words, err := InitFibbleWords(&bank, 1)
+	assert.NilError(t, err)
+	// The true result is "-y-" for abc and abd, and "---" for bcd and cde.
+	first := guessResult(t, "xay", "---")
+	// The true result is "-g-" for abc, "-gg" for abd, "-yg" for bcd, and "--y" for cde.
+	second := guessResult(t, "xbd", "-g-")
+
+	assert.NilError(t, words.Update(&first))
+	assert.NilError(t, words.Update(&second))
+
+	assert.Equal(t, words.NumResults(), 2)
+	assert.Equal(t, words.NumConsistentResults(0), 1)
+	assert.Equal(t, words.NumConsistentResults(1), 2)
+	assert.Equal(t, words.NumConsistentResults(2), 0)
+	assert.Equal(t, words.NumConsistentResults(3), 0)
+	possible := words.PossibleWords()
+	assert.Equal(t, possible.Len(), 1)
+	assert.Equal(t, possible.At(0).String(), "abd")
+}
+
+func TestFibbleWordsUpdateWithNoConsistentWords(t *testing.T) {
+	bank, err := WordBankFromSlice([]string{"abc", "abd"})
+	assert.NilError(t, err)
+	words, err := InitFibbleWords(&bank, 1)
+	assert.NilError(t, err)
+	// abd's true result is "gg-", which has no lies here.
+	result := guessResult(t, "abc", "gg-")
+
+	err = words.Update(&result)
+
+	assert.Error(t, err, "No words are consistent with the result for abc and the results before it.")
+	assert.Equal(t, words.NumResults(), 0)
+	assert.Equal(t, words.NumConsistentResults(1), 0)
+}
+
+func TestFibbleWordsUpdateWrongLength(t *testing.T) {
+	bank, err := WordBankFromSlice([]string{"abc", "abd"})
+	assert.NilError(t, err)
+	words, err := InitFibbleWords(&bank, 1)
+	assert.NilError(t, err)
+	result := guessResult(t, "abcd", "gg--")
+
+	assert.Error(t, words.Update(&result), "The guess (abcd) and results must have 3 letters.")
+}
+
+func TestInitFibbleWordsInvalidNumLies(t *testing.T) {
+	bank, err := WordBankFromSlice([]string{"abc", "abd"})
+	assert.NilError(t, err)
+
+	_, err = InitFibbleWords(&bank, 4)
+	assert.Error(t, err, "The number of lies must be between 0 and the word length (3). Got 4.")
+	_, err = InitFibbleWords(&bank, -1)
+	assert.Error(t, err, "The number of lies must be between 0 and the word length (3). Got -1.")
+}
+
+func TestInitFibbleWordsLongWords(t *testing.T) {
+	bank, err := WordBankFromSlice([]string{strings.Repeat("a", 21), strings.Repeat("b", 21)})
+	assert.NilError(t, err)
+
+	_, err = InitFibbleWords(&bank, 1)
+	assert.Error(t, err, "Fibble is only supported for words with up to 20 letters.")
+	_, err = InitFibbleGuesser(&bank, 1, GuessModeAll)
+	assert.Error(t, err, "Fibble is only supported for words with up to 20 letters.")
+}
+
+func TestAddLies(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	result := guessResult(t, "abcde", "gy-gy")
+	for numLies := 0; numLies <= 5; numLies++ {
+		for n := 0; n < 20; n++ {
+			lie, err := AddLies(&result, numLies, rng)
+			assert.NilError(t, err)
+
+			assert.Equal(t, lie.Guess.String(), "abcde")
+			numDifferent := 0
+			for i, lr := range lie.Results {
+				assert.Assert(t, lr >= LetterResultCorrect && lr <= LetterResultNotPresent)
+				if lr != result.Results[i] {
+					numDifferent++
+				}
+			}
+			assert.Equal(t, numDifferent, numLies)
+		}
+	}
+	// The original is unchanged.
+	assert.Equal(t, LetterResultsString(result.Results), "gy-gy")
+
+	_, err := AddLies(&result, 6, rng)
+	assert.Error(t, err, "The number of lies must be between 0 and the word length (5). Got 6.")
+}
+
+func TestForEachLie(t *testing.T) {
+	compressed, err := CompressResults([]LetterResult{LetterResultCorrect, LetterResultPresentNotHere, LetterResultNotPresent})
+	assert.NilError(t, err)
+	for numLies := 0; numLies <= 3; numLies++ {
+		var lies []string
+		forEachLie(compressed, 3, numLies, func(lie CompressedGuessResult) {
+			results, err := lie.Decompress(3)
+			assert.NilError(t, err)
+			lies = append(lies, LetterResultsString(results))
+		})
+
+		// Choose numLies of the 3 letters, with 2 ways to lie about each.
+		wantLen := []int{1, 6, 12, 8}[numLies]
+		assert.Equal(t, len(lies), wantLen)
+		seen := make(map[string]bool)
+		for _, lie := range lies {
+			assert.Assert(t, !seen[lie], "duplicate lie: %s", lie)
+			seen[lie] = true
+			numDifferent := 0
+			for i := range lie {
+				if lie[i] != "gy-"[i] {
+					numDifferent++
+				}
+			}
+			assert.Equal(t, numDifferent, numLies, lie)
+		}
+	}
+}
+
+func TestPlayFibbleGameWithGuesser(t *testing.T) {
+	bank, err := BuiltinWordBank("1000-improved-shuffled")
+	assert.NilError(t, err)
+	guesser, err := InitFibbleGuesser(&bank, 1, GuessModeAll)
+	assert.NilError(t, err)
+	rng := rand.New(rand.NewSource(1))
+	words := bank.Words()
+
+	for i := 0; i < 30; i++ {
+		objective := words.At(i)
+
+		result, err := PlayFibbleGameWithGuesser(objective, 20, 1, rng, &guesser)
+
+		assert.NilError(t, err)
+		assert.Equal(t, result.Status, GameSuccess, objective)
+		assert.Assert(t, result.Turns[len(result.Turns)-1].Guess.Equal(objective))
+	}
+}
+
+func TestFibbleGuesserWithNoLiesMatchesWordle(t *testing.T) {
+	bank, err := WordBankFromSlice([]string{"abcz", "weyz", "defy", "ghix"})
+	assert.NilError(t, err)
+	guesser, err := InitFibbleGuesser(&bank, 0, GuessModePossible)
+	assert.NilError(t, err)
+
+	result, err := PlayGameWithGuesser(WordFromString("defy"), 10, &guesser)
+
+	assert.NilError(t, err)
+	assert.Equal(t, result.Status, GameSuccess)
+	assert.Assert(t, len(result.Turns) <= 3)
+}
+
+func TestFibbleGuesserCopyIsIndependent(t *testing.T) {
+	bank, err := WordBankFromSlice([]string{"abc", "abd", "bcd", "cde"})
+	assert.NilError(t, err)
+	guesser, err := InitFibbleGuesser(&bank, 1, GuessModeAll)
+	assert.NilError(t, err)
+	copied := guesser.Copy()
+	result := guessResult(t, "abc", "ggy")
+
+	assert.NilError(t, copied.Update(&result))
+
+	assert.Equal(t, copied.PossibleWords().Len(), 1)
+	assert.Equal(t, guesser.PossibleWords().Len(), 4)
+}
+
+func TestPlayFibbleGameWithGuesserInvalidNumLies(t *testing.T) {
+	bank, err := WordBankFromSlice([]string{"abc", "abd"})
+	assert.NilError(t, err)
+	guesser, err := InitFibbleGuesser(&bank, 1, GuessModeAll)
+	assert.NilError(t, err)
+
+	_, err = PlayFibbleGameWithGuesser(WordFromString("abc"), 10, 4, rand.New(rand.NewSource(1)), &guesser)
+
+	assert.Error(t, err, "The number of lies must be between 0 and the word length (3). Got 4.")
+}
@@ -4,6 +4,7 @@ go 1.18
 
 require (
 	golang.org/x/exp v0.0.0-20220613132600-b0d781184e0d
+	golang.org/x/text v0.14.0
 	gotest.tools/v3 v3.3.0
 )
 
@@ -1,8 +1,10 @@
 package go_wordle_solver
 
 import (
+	"errors"
 	"fmt"
-	"os"
+	"runtime"
+	"sync/atomic"
 	"testing"
 
 	"gotest.tools/v3/assert"
@@ -98,7 +100,24 @@ func TestRandomGuesserInvalidUpdateFails(t *testing.T) {
 			LetterResultNotPresent,
 		},
 	})
-	assert.Error(t, err, "Can't set letter to not here at index 0 since it's already marked as here.")
+	assert.Error(t, err, "The result for cde conflicts with turn 2 (bcd) for the letter c at position 1. Can't set letter to not here at index 0 since it's already marked as here.")
+}
+
+func TestMaxScoreGuesserScorerUpdateFailureLeavesGuesserUnchanged(t *testing.T) {
+	bank, err := WordBankFromSlice([]string{"abc", "abd", "abe"})
+	assert.NilError(t, err)
+	scorer := fixedScorer{scores: map[string]int64{"abc": 1, "abd": 2, "abe": 3}, updateErr: errors.New("Nope.")}
+	guesser := InitMaxScoreGuesser(&bank, &scorer, GuessModeAll)
+	result := guessResult(t, "abe", "gg-")
+
+	assert.Error(t, guesser.Update(&result), "Nope.")
+
+	assert.Equal(t, guesser.PossibleWords().Len(), 3)
+	assert.Equal(t, guesser.unguessedWords.Len(), 3)
+	scorer.updateErr = nil
+	assert.NilError(t, guesser.Update(&result))
+	assert.Equal(t, guesser.PossibleWords().Len(), 2)
+	assert.Equal(t, guesser.unguessedWords.Len(), 2)
 }
 
 func TestPlayGameWithUnknownWordRandom(t *testing.T) {
@@ -145,12 +164,125 @@ func TestPlayGameWithKnownWordMaxScore(t *testing.T) {
 	assert.DeepEqual(t, got.Turns[len(got.Turns)-1].Guess, WordFromString("abcz"))
 }
 
-func BenchmarkPlayGameWithRandom(b *testing.B) {
-	f, err := os.Open("../data/1000-improved-words-shuffled.txt")
-	if err != nil {
-		b.Fatal(err)
+func TestMaxScoreGuesserParallelMatchesSequential(t *testing.T) {
+	bank, err := BuiltinWordBank("1000-improved-shuffled")
+	assert.NilError(t, err)
+	words := bank.Words()
+	scorer := InitLocatedLettersScorer(&bank)
+
+	for _, mode := range []GuessMode{GuessModeAll, GuessModePossible} {
+		sequential := InitMaxScoreGuesser(&bank, &scorer, mode)
+		sequential.SetEndgameThreshold(0)
+		parallel := sequential.Copy().(*MaxScoreGuesser[*LocatedLettersScorer])
+		parallel.SetNumThreads(4)
+
+		for i := 0; i < words.Len(); i += 20 {
+			want, err := PlayGameWithGuesser(words.At(i), 20, &sequential)
+			assert.NilError(t, err)
+			got, err := PlayGameWithGuesser(words.At(i), 20, parallel)
+			assert.NilError(t, err)
+
+			assert.Equal(t, got.Status, want.Status)
+			assert.DeepEqual(t, turnGuesses(got), turnGuesses(want))
+		}
 	}
-	bank, err := WordBankFromReader(f)
+}
+
+func turnGuesses(result GameResult) []string {
+	guesses := make([]string, len(result.Turns))
+	for i, turn := range result.Turns {
+		guesses[i] = turn.Guess.String()
+	}
+	return guesses
+}
+
+// concurrencyScorer gives every word the same score, and records the most calls to ScoreWord that
+// were in progress at once.
+type concurrencyScorer struct {
+	isSafe        bool
+	numInProgress int32
+	maxInProgress int32
+}
+
+func (self *concurrencyScorer) Copy() WordScorer {
+	return &concurrencyScorer{isSafe: self.isSafe}
+}
+
+func (self *concurrencyScorer) Reset(pw *PossibleWords) {}
+
+func (self *concurrencyScorer) Update(latestGuess Word, pw *PossibleWords) error {
+	return nil
+}
+
+func (self *concurrencyScorer) IsSafeForConcurrentUse() bool {
+	return self.isSafe
+}
+
+func (self *concurrencyScorer) ScoreWord(w Word) int64 {
+	numInProgress := atomic.AddInt32(&self.numInProgress, 1)
+	for {
+		max := atomic.LoadInt32(&self.maxInProgress)
+		if numInProgress <= max || atomic.CompareAndSwapInt32(&self.maxInProgress, max, numInProgress) {
+			break
+		}
+	}
+	// Give the other goroutines a chance to run.
+	runtime.Gosched()
+	atomic.AddInt32(&self.numInProgress, -1)
+	return 0
+}
+
+func TestMaxScoreGuesserOnlyScoresInParallelWhenSafe(t *testing.T) {
+	bank, err := BuiltinWordBank("1000-improved-shuffled")
+	assert.NilError(t, err)
+
+	for _, isSafe := range []bool{false, true} {
+		scorer := concurrencyScorer{isSafe: isSafe}
+		guesser := InitMaxScoreGuesser(&bank, &scorer, GuessModeAll)
+		guesser.SetNumThreads(4)
+
+		guess := guesser.SelectNextGuess()
+
+		assert.Assert(t, guess.HasValue())
+		if isSafe {
+			assert.Assert(t, scorer.maxInProgress > 1)
+		} else {
+			assert.Equal(t, scorer.maxInProgress, int32(1))
+		}
+	}
+}
+
+func TestScoreWords(t *testing.T) {
+	bank, err := BuiltinWordBank("1000-improved-shuffled")
+	assert.NilError(t, err)
+	words := bank.Words()
+	score := func(w Word) int64 {
+		return int64(w.At(0))*100 + int64(w.At(4))
+	}
+
+	want := scoreWords(&words, score, 1)
+
+	assert.Equal(t, len(want), words.Len())
+	for i := 0; i < words.Len(); i++ {
+		assert.Equal(t, want[i], score(words.At(i)))
+	}
+	for _, numThreads := range []int{0, 3, 100} {
+		assert.DeepEqual(t, scoreWords(&words, score, numThreads), want)
+	}
+}
+
+func TestIndexOfMaxScore(t *testing.T) {
+	best, scoresAllSame := indexOfMaxScore([]int64{1, 3, 2, 3})
+	assert.Equal(t, best, 1)
+	assert.Assert(t, !scoresAllSame)
+
+	best, scoresAllSame = indexOfMaxScore([]int64{2, 2, 2})
+	assert.Equal(t, best, 0)
+	assert.Assert(t, scoresAllSame)
+}
+
+func BenchmarkPlayGameWithRandom(b *testing.B) {
+	bank, err := BuiltinWordBank("1000-improved-shuffled")
 	if err != nil {
 		b.Fatal(err)
 	}
@@ -172,11 +304,21 @@ func BenchmarkPlayGameWithRandom(b *testing.B) {
 }
 
 func BenchmarkPlayGameWithMaxScore(b *testing.B) {
-	f, err := os.Open("../data/1000-improved-words-shuffled.txt")
-	if err != nil {
-		b.Fatal(err)
-	}
-	bank, err := WordBankFromReader(f)
+	benchmarkPlayGameWithMaxScore(b, DefaultEndgameThreshold, 1)
+}
+
+func BenchmarkPlayGameWithMaxScoreNoEndgame(b *testing.B) {
+	benchmarkPlayGameWithMaxScore(b, 0, 1)
+}
+
+func BenchmarkPlayGameWithMaxScoreParallel(b *testing.B) {
+	benchmarkPlayGameWithMaxScore(b, DefaultEndgameThreshold, maxThreads)
+}
+
+// benchmarkPlayGameWithMaxScore also reports the average number of guesses per game, to show the
+// effect of the endgame search.
+func benchmarkPlayGameWithMaxScore(b *testing.B, endgameThreshold, numThreads int) {
+	bank, err := BuiltinWordBank("1000-improved-shuffled")
 	if err != nil {
 		b.Fatal(err)
 	}
@@ -185,8 +327,11 @@ func BenchmarkPlayGameWithMaxScore(b *testing.B) {
 		b.Fatal(err)
 	}
 	guesser := InitMaxScoreGuesser(&bank, &scorer, GuessModeAll)
+	guesser.SetEndgameThreshold(endgameThreshold)
+	guesser.SetNumThreads(numThreads)
 	allWords := bank.Words()
 	numWords := allWords.Len()
+	numGuesses := 0
 
 	for i := 0; i < b.N; i++ {
 		guesser.Reset()
@@ -198,5 +343,7 @@ func BenchmarkPlayGameWithMaxScore(b *testing.B) {
 		if result.Status != GameSuccess {
 			b.Fatalf("Game failed for word %s, result: %v", word, result)
 		}
+		numGuesses += len(result.Turns)
 	}
+	b.ReportMetric(float64(numGuesses)/float64(b.N), "guesses/game")
 }
@@ -0,0 +1,49 @@
+package go_wordle_solver
+
+import "fmt"
+
+// CheckHardModeGuess returns an error if the given guess does not use all the hints revealed by
+// the previous results, as required by Wordle's hard mode.
+//
+// In hard mode, letters marked [LetterResultCorrect] must be guessed in the same location again,
+// and letters marked [LetterResultPresentNotHere] must be included in the guess. Letters that are
+// known not to be present may still be guessed.
+func CheckHardModeGuess(history []GuessResult, guess Word) error {
+	requiredCounts := make(map[rune]int)
+	for _, result := range history {
+		if result.Guess.Len() != guess.Len() {
+			return fmt.Errorf("The guess (%s) must be the same length as previous guesses (%v).", guess, result.Guess.Len())
+		}
+		counts := make(map[rune]int)
+		for i, lr := range result.Results {
+			letter := result.Guess.At(i)
+			switch lr {
+			case LetterResultCorrect:
+				if guess.At(i) != letter {
+					return fmt.Errorf("Letter %v must be '%c'.", i+1, letter)
+				}
+				counts[letter]++
+			case LetterResultPresentNotHere:
+				counts[letter]++
+			}
+		}
+		for letter, count := range counts {
+			if count > requiredCounts[letter] {
+				requiredCounts[letter] = count
+			}
+		}
+	}
+	for i := 0; i < guess.Len(); i++ {
+		requiredCounts[guess.At(i)]--
+	}
+	// Report missing letters in the order they were revealed.
+	for _, result := range history {
+		for i := 0; i < result.Guess.Len(); i++ {
+			letter := result.Guess.At(i)
+			if requiredCounts[letter] > 0 {
+				return fmt.Errorf("The guess must contain '%c'.", letter)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,49 @@
+package go_wordle_solver
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestCheckHardModeGuess(t *testing.T) {
+	history := []GuessResult{
+		{WordFromString("abbey"), []LetterResult{
+			LetterResultCorrect,
+			LetterResultPresentNotHere,
+			LetterResultNotPresent,
+			LetterResultNotPresent,
+			LetterResultPresentNotHere,
+		}},
+	}
+
+	assert.NilError(t, CheckHardModeGuess(nil, WordFromString("zzzzz")))
+	assert.NilError(t, CheckHardModeGuess(history, WordFromString("ayebz")))
+	assert.NilError(t, CheckHardModeGuess(history, WordFromString("abbey")))
+	assert.ErrorContains(t, CheckHardModeGuess(history, WordFromString("bayou")), "Letter 1 must be 'a'.")
+	assert.ErrorContains(t, CheckHardModeGuess(history, WordFromString("ayzzz")), "The guess must contain 'b'.")
+	assert.ErrorContains(t, CheckHardModeGuess(history, WordFromString("abzzz")), "The guess must contain 'y'.")
+	assert.ErrorContains(t, CheckHardModeGuess(history, WordFromString("abc")), "same length")
+}
+
+func TestCheckHardModeGuessRepeatedLetters(t *testing.T) {
+	history := []GuessResult{
+		{WordFromString("eerie"), []LetterResult{
+			LetterResultPresentNotHere,
+			LetterResultPresentNotHere,
+			LetterResultNotPresent,
+			LetterResultNotPresent,
+			LetterResultNotPresent,
+		}},
+		{WordFromString("theme"), []LetterResult{
+			LetterResultNotPresent,
+			LetterResultNotPresent,
+			LetterResultCorrect,
+			LetterResultNotPresent,
+			LetterResultNotPresent,
+		}},
+	}
+
+	assert.NilError(t, CheckHardModeGuess(history, WordFromString("zzeze")))
+	assert.ErrorContains(t, CheckHardModeGuess(history, WordFromString("zzezz")), "The guess must contain 'e'.")
+}
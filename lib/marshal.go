@@ -0,0 +1,632 @@
+package go_wordle_solver
+
+import (
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+
+	"golang.org/x/exp/slices"
+)
+
+// This file provides JSON and compact binary encodings for words, results, and restrictions, so
+// that games can be saved and reloaded.
+
+// MarshalText encodes this word as its string.
+func (self Word) MarshalText() ([]byte, error) {
+	return []byte(self.String()), nil
+}
+
+// UnmarshalText decodes a word from its string. The word is not normalized.
+func (self *Word) UnmarshalText(text []byte) error {
+	if !utf8.Valid(text) {
+		return errors.New("Words must be valid UTF-8.")
+	}
+	*self = WordFromString(string(text))
+	return nil
+}
+
+// MarshalText encodes this status as its string, e.g. "success".
+func (gs GameStatus) MarshalText() ([]byte, error) {
+	switch gs {
+	case GameSuccess, GameFailure:
+		return []byte(gs.String()), nil
+	}
+	return nil, fmt.Errorf("Can't marshal invalid GameStatus %v.", int(gs))
+}
+
+// UnmarshalText decodes a status from its string, e.g. "success".
+func (gs *GameStatus) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case GameSuccess.String():
+		*gs = GameSuccess
+	case GameFailure.String():
+		*gs = GameFailure
+	default:
+		return fmt.Errorf("Invalid game status %q. Expected \"success\" or \"failure\".", text)
+	}
+	return nil
+}
+
+type guessResultJSON struct {
+	Guess   Word   `json:"guess"`
+	Results string `json:"results"`
+}
+
+// MarshalJSON encodes this result as an object with the guess and its compact results string, e.g.
+// {"guess":"abbey","results":"gy--g"}. See [LetterResultsString].
+func (self GuessResult) MarshalJSON() ([]byte, error) {
+	return json.Marshal(guessResultJSON{self.Guess, LetterResultsString(self.Results)})
+}
+
+// UnmarshalJSON decodes a result encoded by [GuessResult.MarshalJSON].
+func (self *GuessResult) UnmarshalJSON(data []byte) error {
+	var decoded guessResultJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	results, err := LetterResultsFromString(decoded.Results)
+	if err != nil {
+		return err
+	}
+	if len(results) != decoded.Guess.Len() {
+		return fmt.Errorf("The results (%s) must be the same length as the guess (%s).", decoded.Results, decoded.Guess)
+	}
+	*self = GuessResult{decoded.Guess, results}
+	return nil
+}
+
+type restrictionsJSON struct {
+	WordLength        uint8        `json:"word_length"`
+	PresentLetters    []letterJSON `json:"present_letters"`
+	NotPresentLetters string       `json:"not_present_letters"`
+	OtherLetters      []letterJSON `json:"other_letters,omitempty"`
+	NoRepeatedLetters bool         `json:"no_repeated_letters,omitempty"`
+}
+
+type letterJSON struct {
+	Letter            string `json:"letter"`
+	KnownLocations    []int  `json:"known_locations,omitempty"`
+	ExcludedLocations []int  `json:"excluded_locations,omitempty"`
+	MinCount          uint8  `json:"min_count,omitempty"`
+	ExactCount        *uint8 `json:"exact_count,omitempty"`
+	MaxCount          *uint8 `json:"max_count,omitempty"`
+}
+
+// MarshalJSON encodes these restrictions as an object describing what is known about each letter.
+// Locations start from 0.
+func (self *WordRestrictions) MarshalJSON() ([]byte, error) {
+	summary := self.Summary()
+	encoded := restrictionsJSON{
+		WordLength:        summary.WordLength,
+		PresentLetters:    make([]letterJSON, len(summary.PresentLetters)),
+		NotPresentLetters: string(summary.NotPresentLetters),
+		NoRepeatedLetters: summary.NoRepeatedLetters,
+	}
+	for i, ls := range summary.PresentLetters {
+		encoded.PresentLetters[i] = letterToJSON(&ls)
+	}
+	for _, ls := range summary.OtherLetters {
+		encoded.OtherLetters = append(encoded.OtherLetters, letterToJSON(&ls))
+	}
+	return json.Marshal(encoded)
+}
+
+func letterToJSON(ls *LetterSummary) letterJSON {
+	encoded := letterJSON{
+		Letter:   string(ls.Letter),
+		MinCount: ls.MinCount,
+	}
+	for _, location := range ls.KnownLocations {
+		encoded.KnownLocations = append(encoded.KnownLocations, int(location))
+	}
+	for _, location := range ls.ExcludedLocations {
+		encoded.ExcludedLocations = append(encoded.ExcludedLocations, int(location))
+	}
+	if ls.ExactCount.HasValue() {
+		exactCount := ls.ExactCount.Value()
+		encoded.ExactCount = &exactCount
+	}
+	if ls.MaxCount.HasValue() {
+		maxCount := ls.MaxCount.Value()
+		encoded.MaxCount = &maxCount
+	}
+	return encoded
+}
+
+// UnmarshalJSON decodes restrictions encoded by [WordRestrictions.MarshalJSON].
+//
+// Returns an error if the restrictions are inconsistent.
+func (self *WordRestrictions) UnmarshalJSON(data []byte) error {
+	var decoded restrictionsJSON
+	if err := json.Unmarshal(data, &decoded); err != nil {
+		return err
+	}
+	saved := savedRestrictions{
+		wordLength:        decoded.WordLength,
+		presentLetters:    make([]savedLetter, len(decoded.PresentLetters)),
+		notPresentLetters: []rune(decoded.NotPresentLetters),
+		noRepeatedLetters: decoded.NoRepeatedLetters,
+	}
+	var err error
+	for i := range decoded.PresentLetters {
+		if saved.presentLetters[i], err = letterFromJSON(&decoded.PresentLetters[i], decoded.WordLength); err != nil {
+			return err
+		}
+	}
+	for i := range decoded.OtherLetters {
+		letter, err := letterFromJSON(&decoded.OtherLetters[i], decoded.WordLength)
+		if err != nil {
+			return err
+		}
+		saved.otherLetters = append(saved.otherLetters, letter)
+	}
+	restrictions, err := saved.restore()
+	if err != nil {
+		return err
+	}
+	*self = restrictions
+	return nil
+}
+
+func letterFromJSON(encoded *letterJSON, wordLength uint8) (savedLetter, error) {
+	if utf8.RuneCountInString(encoded.Letter) != 1 {
+		return savedLetter{}, fmt.Errorf("Invalid restrictions. Each letter must be a single letter, not %q.", encoded.Letter)
+	}
+	letter, _ := utf8.DecodeRuneInString(encoded.Letter)
+	saved := savedLetter{
+		letter:   letter,
+		minCount: encoded.MinCount,
+		states:   make([]locatedLetterState, wordLength),
+	}
+	if encoded.ExactCount != nil {
+		saved.maybeRequiredCount = OptionalOf(*encoded.ExactCount)
+	}
+	if encoded.MaxCount != nil {
+		saved.maybeMaxCount = OptionalOf(*encoded.MaxCount)
+	}
+	for _, locations := range []struct {
+		values []int
+		state  locatedLetterState
+	}{{encoded.KnownLocations, llsHere}, {encoded.ExcludedLocations, llsNotHere}} {
+		for _, location := range locations.values {
+			if location < 0 || location >= int(wordLength) {
+				return savedLetter{}, fmt.Errorf("Invalid restrictions. Location %v of letter %c is out of range.", location, letter)
+			}
+			if saved.states[location] != llsUnknown {
+				return savedLetter{}, fmt.Errorf("Invalid restrictions. Location %v of letter %c is both known and excluded.", location, letter)
+			}
+			saved.states[location] = locations.state
+		}
+	}
+	return saved, nil
+}
+
+// savedRestrictions holds the saved state of a [WordRestrictions].
+type savedRestrictions struct {
+	wordLength        uint8
+	presentLetters    []savedLetter
+	notPresentLetters []rune
+	// Letters that aren't known to be present, but have excluded locations or a maximum count.
+	otherLetters      []savedLetter
+	noRepeatedLetters bool
+}
+
+// savedLetter holds the saved state of a letter.
+type savedLetter struct {
+	letter             rune
+	minCount           uint8
+	maybeRequiredCount Optional[uint8]
+	maybeMaxCount      Optional[uint8]
+	states             []locatedLetterState
+}
+
+// restore reconstructs restrictions from their saved state, checking that the state is consistent.
+func (self *savedRestrictions) restore() (WordRestrictions, error) {
+	wordLength := self.wordLength
+	if wordLength == 0 {
+		return WordRestrictions{}, errors.New("Invalid restrictions. The word length must be at least 1.")
+	}
+	restrictions := InitWordRestrictions(wordLength)
+	knownLocations := make([]bool, wordLength)
+	for _, saved := range self.presentLetters {
+		if _, isPresent := restrictions.presentLetters[saved.letter]; isPresent {
+			return WordRestrictions{}, fmt.Errorf("Invalid restrictions. The letter %c is present more than once.", saved.letter)
+		}
+		presence := newPresentLetter(wordLength)
+		for location, state := range saved.states {
+			switch state {
+			case llsUnknown:
+				continue
+			case llsHere:
+				if knownLocations[location] {
+					return WordRestrictions{}, fmt.Errorf("Invalid restrictions. Multiple letters are known to be at location %v.", location)
+				}
+				knownLocations[location] = true
+				presence.numHere++
+			case llsNotHere:
+				presence.numNotHere++
+			default:
+				return WordRestrictions{}, fmt.Errorf("Invalid restrictions. Invalid state for letter %c at location %v.", saved.letter, location)
+			}
+			presence.setState(uint8(location), state)
+		}
+		maxCount := wordLength - presence.numNotHere
+		if saved.minCount < 1 || saved.minCount < presence.numHere || saved.minCount > maxCount {
+			return WordRestrictions{}, fmt.Errorf("Invalid restrictions. The minimum count for letter %c (%v) is inconsistent with its locations.", saved.letter, saved.minCount)
+		}
+		presence.minCount = saved.minCount
+		if saved.maybeRequiredCount.HasValue() {
+			requiredCount := saved.maybeRequiredCount.Value()
+			if requiredCount < saved.minCount || requiredCount > maxCount {
+				return WordRestrictions{}, fmt.Errorf("Invalid restrictions. The exact count for letter %c (%v) is inconsistent with its locations.", saved.letter, requiredCount)
+			}
+			presence.maybeRequiredCount = saved.maybeRequiredCount
+		}
+		restrictions.presentLetters[saved.letter] = presence
+	}
+	for _, letter := range self.notPresentLetters {
+		if _, isPresent := restrictions.presentLetters[letter]; isPresent {
+			return WordRestrictions{}, fmt.Errorf("Invalid restrictions. The letter %c can't be both present and not present.", letter)
+		}
+		restrictions.addNotPresentLetter(letter)
+	}
+	// The remaining restrictions are validated as they're added.
+	for _, saved := range self.otherLetters {
+		if restrictions.State(saved.letter, 0) != LetterRestrictionUnknown || saved.minCount > 0 || saved.maybeRequiredCount.HasValue() {
+			return WordRestrictions{}, fmt.Errorf("Invalid restrictions. The letter %c can't be both present and not known to be present.", saved.letter)
+		}
+		for location, state := range saved.states {
+			if state == llsHere {
+				return WordRestrictions{}, fmt.Errorf("Invalid restrictions. The letter %c can't be known to be at %v, but not known to be present.", saved.letter, location)
+			}
+			if state == llsNotHere {
+				if err := restrictions.SetLetterNotAt(saved.letter, uint8(location)); err != nil {
+					return WordRestrictions{}, fmt.Errorf("Invalid restrictions. %w", err)
+				}
+			}
+		}
+	}
+	for _, saved := range append(slices.Clone(self.presentLetters), self.otherLetters...) {
+		if !saved.maybeMaxCount.HasValue() {
+			continue
+		}
+		if err := restrictions.SetLetterMaxCount(saved.letter, saved.maybeMaxCount.Value()); err != nil {
+			return WordRestrictions{}, fmt.Errorf("Invalid restrictions. %w", err)
+		}
+	}
+	if self.noRepeatedLetters {
+		if err := restrictions.SetNoRepeatedLetters(); err != nil {
+			return WordRestrictions{}, fmt.Errorf("Invalid restrictions. %w", err)
+		}
+	}
+	if err := restrictions.inferFromLimits(); err != nil {
+		return WordRestrictions{}, fmt.Errorf("Invalid restrictions. %w", err)
+	}
+	// The restrictions weren't built turn by turn, so the turns added above can't explain conflicts.
+	restrictions.updates = nil
+	return restrictions, nil
+}
+
+// The compact binary encodings below use unsigned varints for all lengths, counts, and letters.
+// Letter results and located states are packed with 2 bits each.
+
+// MarshalBinary encodes this word in a compact binary form.
+func (self Word) MarshalBinary() ([]byte, error) {
+	return appendWord(nil, self), nil
+}
+
+// UnmarshalBinary decodes a word encoded by [Word.MarshalBinary].
+func (self *Word) UnmarshalBinary(data []byte) error {
+	r := binaryReader{data: data}
+	word := r.word()
+	if err := r.finish(); err != nil {
+		return err
+	}
+	*self = word
+	return nil
+}
+
+// MarshalBinary encodes this result in a compact binary form.
+func (self GuessResult) MarshalBinary() ([]byte, error) {
+	if len(self.Results) != self.Guess.Len() {
+		return nil, fmt.Errorf("The results must be the same length as the guess (%s).", self.Guess)
+	}
+	return appendGuessResult(nil, &self), nil
+}
+
+// UnmarshalBinary decodes a result encoded by [GuessResult.MarshalBinary].
+func (self *GuessResult) UnmarshalBinary(data []byte) error {
+	r := binaryReader{data: data}
+	result := r.guessResult()
+	if err := r.finish(); err != nil {
+		return err
+	}
+	*self = result
+	return nil
+}
+
+// MarshalBinary encodes this turn in a compact binary form.
+func (self TurnData) MarshalBinary() ([]byte, error) {
+	return appendTurnData(nil, &self), nil
+}
+
+// UnmarshalBinary decodes a turn encoded by [TurnData.MarshalBinary].
+func (self *TurnData) UnmarshalBinary(data []byte) error {
+	r := binaryReader{data: data}
+	turn := r.turnData()
+	if err := r.finish(); err != nil {
+		return err
+	}
+	*self = turn
+	return nil
+}
+
+// MarshalBinary encodes this game result in a compact binary form.
+func (self GameResult) MarshalBinary() ([]byte, error) {
+	if _, err := self.Status.MarshalText(); err != nil {
+		return nil, err
+	}
+	data := []byte{byte(self.Status)}
+	data = appendUvarint(data, uint64(len(self.Turns)))
+	for i := range self.Turns {
+		data = appendTurnData(data, &self.Turns[i])
+	}
+	return data, nil
+}
+
+// UnmarshalBinary decodes a game result encoded by [GameResult.MarshalBinary].
+func (self *GameResult) UnmarshalBinary(data []byte) error {
+	r := binaryReader{data: data}
+	status := GameStatus(r.byte())
+	numTurns := r.length()
+	turns := make([]TurnData, 0, numTurns)
+	for i := 0; i < numTurns && r.err == nil; i++ {
+		turns = append(turns, r.turnData())
+	}
+	if err := r.finish(); err != nil {
+		return err
+	}
+	if status != GameSuccess && status != GameFailure {
+		return fmt.Errorf("Invalid game status %v.", int(status))
+	}
+	*self = GameResult{status, turns}
+	return nil
+}
+
+// MarshalBinary encodes these restrictions in a compact binary form.
+func (self *WordRestrictions) MarshalBinary() ([]byte, error) {
+	summary := self.Summary()
+	data := []byte{self.wordLength}
+	for _, letters := range [][]LetterSummary{summary.PresentLetters, summary.OtherLetters} {
+		data = appendUvarint(data, uint64(len(letters)))
+		for i := range letters {
+			data = appendLetterSummary(data, &letters[i], self.wordLength)
+		}
+	}
+	data = appendUvarint(data, uint64(len(summary.NotPresentLetters)))
+	for _, letter := range summary.NotPresentLetters {
+		data = appendUvarint(data, uint64(letter))
+	}
+	var noRepeatedLetters byte
+	if summary.NoRepeatedLetters {
+		noRepeatedLetters = 1
+	}
+	return append(data, noRepeatedLetters), nil
+}
+
+// UnmarshalBinary decodes restrictions encoded by [WordRestrictions.MarshalBinary].
+//
+// Returns an error if the restrictions are inconsistent.
+func (self *WordRestrictions) UnmarshalBinary(data []byte) error {
+	r := binaryReader{data: data}
+	saved := savedRestrictions{wordLength: r.byte()}
+	for _, letters := range []*[]savedLetter{&saved.presentLetters, &saved.otherLetters} {
+		numLetters := r.length()
+		for i := 0; i < numLetters && r.err == nil; i++ {
+			*letters = append(*letters, r.savedLetter(saved.wordLength))
+		}
+	}
+	numNotPresent := r.length()
+	for i := 0; i < numNotPresent && r.err == nil; i++ {
+		saved.notPresentLetters = append(saved.notPresentLetters, r.letter())
+	}
+	switch r.byte() {
+	case 0:
+	case 1:
+		saved.noRepeatedLetters = true
+	default:
+		if r.err == nil {
+			r.err = errors.New("Invalid binary data. Invalid repeated letters flag.")
+		}
+	}
+	if err := r.finish(); err != nil {
+		return err
+	}
+	restrictions, err := saved.restore()
+	if err != nil {
+		return err
+	}
+	*self = restrictions
+	return nil
+}
+
+// appendLetterSummary appends the letter, its counts, and its located states. Counts that aren't
+// known are written as 0, which is never valid for a letter in the summary.
+func appendLetterSummary(data []byte, ls *LetterSummary, wordLength uint8) []byte {
+	data = appendUvarint(data, uint64(ls.Letter))
+	data = append(data, ls.MinCount, ls.ExactCount.Value(), ls.MaxCount.Value())
+	states := make([]locatedLetterState, wordLength)
+	for _, location := range ls.KnownLocations {
+		states[location] = llsHere
+	}
+	for _, location := range ls.ExcludedLocations {
+		states[location] = llsNotHere
+	}
+	return appendPacked2Bits(data, len(states), func(i int) uint8 {
+		return uint8(states[i])
+	})
+}
+
+func appendUvarint(data []byte, value uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	n := binary.PutUvarint(buf[:], value)
+	return append(data, buf[:n]...)
+}
+
+func appendWord(data []byte, word Word) []byte {
+	str := word.String()
+	data = appendUvarint(data, uint64(len(str)))
+	return append(data, str...)
+}
+
+func appendGuessResult(data []byte, result *GuessResult) []byte {
+	data = appendWord(data, result.Guess)
+	return appendPacked2Bits(data, len(result.Results), func(i int) uint8 {
+		return uint8(result.Results[i])
+	})
+}
+
+func appendTurnData(data []byte, turn *TurnData) []byte {
+	data = appendWord(data, turn.Guess)
+	return appendUvarint(data, uint64(turn.NumPossibleWordsBeforeGuess))
+}
+
+// appendPacked2Bits appends n 2-bit values, packed four per byte.
+func appendPacked2Bits(data []byte, n int, value func(i int) uint8) []byte {
+	for start := 0; start < n; start += 4 {
+		var b byte
+		for i := start; i < n && i < start+4; i++ {
+			b |= (value(i) & 0b11) << (2 * (i - start))
+		}
+		data = append(data, b)
+	}
+	return data
+}
+
+// binaryReader reads values written by the append functions above.
+//
+// Once an error occurs, all further reads return zero values, and the error is reported by
+// [binaryReader.finish].
+type binaryReader struct {
+	data []byte
+	err  error
+}
+
+var errUnexpectedEnd = errors.New("Invalid binary data. Unexpected end of data.")
+
+func (r *binaryReader) byte() byte {
+	if r.err != nil {
+		return 0
+	}
+	if len(r.data) == 0 {
+		r.err = errUnexpectedEnd
+		return 0
+	}
+	b := r.data[0]
+	r.data = r.data[1:]
+	return b
+}
+
+func (r *binaryReader) uvarint() uint64 {
+	if r.err != nil {
+		return 0
+	}
+	value, n := binary.Uvarint(r.data)
+	if n <= 0 {
+		r.err = errUnexpectedEnd
+		return 0
+	}
+	r.data = r.data[n:]
+	return value
+}
+
+// length reads a length, which must not exceed the remaining data since every element is at least
+// one byte.
+func (r *binaryReader) length() int {
+	length := r.uvarint()
+	if r.err == nil && length > uint64(len(r.data)) {
+		r.err = errUnexpectedEnd
+		return 0
+	}
+	return int(length)
+}
+
+func (r *binaryReader) letter() rune {
+	letter := r.uvarint()
+	if r.err == nil && (letter > utf8.MaxRune || !utf8.ValidRune(rune(letter))) {
+		r.err = fmt.Errorf("Invalid binary data. Invalid letter %v.", letter)
+		return 0
+	}
+	return rune(letter)
+}
+
+func (r *binaryReader) word() Word {
+	length := r.length()
+	if r.err != nil {
+		return Word{}
+	}
+	str := r.data[:length]
+	r.data = r.data[length:]
+	if !utf8.Valid(str) {
+		r.err = errors.New("Invalid binary data. Words must be valid UTF-8.")
+		return Word{}
+	}
+	return WordFromString(string(str))
+}
+
+func (r *binaryReader) guessResult() GuessResult {
+	guess := r.word()
+	results := make([]LetterResult, guess.Len())
+	r.packed2Bits(len(results), func(i int, value uint8) {
+		results[i] = LetterResult(value)
+	})
+	return GuessResult{guess, results}
+}
+
+func (r *binaryReader) turnData() TurnData {
+	guess := r.word()
+	numPossibleWords := r.uvarint()
+	return TurnData{guess, uint(numPossibleWords)}
+}
+
+// savedLetter reads a letter written by appendLetterSummary.
+func (r *binaryReader) savedLetter(wordLength uint8) savedLetter {
+	saved := savedLetter{
+		letter:   r.letter(),
+		minCount: r.byte(),
+		states:   make([]locatedLetterState, wordLength),
+	}
+	if requiredCount := r.byte(); requiredCount > 0 {
+		saved.maybeRequiredCount = OptionalOf(requiredCount)
+	}
+	if maxCount := r.byte(); maxCount > 0 {
+		saved.maybeMaxCount = OptionalOf(maxCount)
+	}
+	r.packed2Bits(int(wordLength), func(i int, value uint8) {
+		saved.states[i] = locatedLetterState(value)
+	})
+	return saved
+}
+
+// packed2Bits reads n 2-bit values written by appendPacked2Bits.
+func (r *binaryReader) packed2Bits(n int, fn func(i int, value uint8)) {
+	for start := 0; start < n && r.err == nil; start += 4 {
+		b := r.byte()
+		for i := start; i < n && i < start+4; i++ {
+			fn(i, (b>>(2*(i-start)))&0b11)
+		}
+	}
+}
+
+// finish returns any error that occurred, or an error if there is unread data.
+func (r *binaryReader) finish() error {
+	if r.err != nil {
+		return r.err
+	}
+	if len(r.data) > 0 {
+		return fmt.Errorf("Invalid binary data. Found %v unexpected trailing bytes.", len(r.data))
+	}
+	return nil
+}
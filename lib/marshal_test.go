@@ -0,0 +1,190 @@
+package go_wordle_solver
+
+import (
+	"encoding/json"
+	"math/rand"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+// assertSameRestrictions checks that both restrictions allow exactly the same words from the bank.
+func assertSameRestrictions(t *testing.T, got, want *WordRestrictions, words PossibleWords) {
+	t.Helper()
+	assert.Equal(t, got.Summary().String(), want.Summary().String())
+	assert.Equal(t, got.Pattern(), want.Pattern())
+	for i := 0; i < words.Len(); i++ {
+		word := words.At(i)
+		assert.Equal(t, got.IsSatisfiedBy(word), want.IsSatisfiedBy(word), "word: %s", word)
+	}
+}
+
+func TestWordRestrictionsJSON(t *testing.T) {
+	restrictions := restrictionsForGuesses(t, "xrxae", "aster", "lrace", "eerie")
+
+	data, err := json.Marshal(&restrictions)
+	assert.NilError(t, err)
+
+	assert.Equal(t, string(data), `{"word_length":5,"present_letters":[`+
+		`{"letter":"a","known_locations":[3],"excluded_locations":[0,1,2,4],"min_count":1,"exact_count":1},`+
+		`{"letter":"e","known_locations":[4],"excluded_locations":[0,1,2,3],"min_count":1,"exact_count":1},`+
+		`{"letter":"r","known_locations":[1],"excluded_locations":[2,3,4],"min_count":1}],`+
+		`"not_present_letters":"cilst"}`)
+	var decoded WordRestrictions
+	assert.NilError(t, json.Unmarshal(data, &decoded))
+	bank, err := BuiltinWordBank("1000-wordle-shuffled")
+	assert.NilError(t, err)
+	assertSameRestrictions(t, &decoded, &restrictions, bank.Words())
+}
+
+func TestWordRestrictionsRoundTripRandom(t *testing.T) {
+	bank, err := BuiltinWordBank("1000-wordle-shuffled")
+	assert.NilError(t, err)
+	words := bank.Words()
+	rng := rand.New(rand.NewSource(38))
+
+	for trial := 0; trial < 50; trial++ {
+		objective := words.At(rng.Intn(words.Len()))
+		restrictions := InitWordRestrictions(bank.WordLength())
+		numGuesses := rng.Intn(5)
+		for i := 0; i < numGuesses; i++ {
+			result, err := GetResultForGuess(objective, words.At(rng.Intn(words.Len())))
+			assert.NilError(t, err)
+			assert.NilError(t, restrictions.Update(&result))
+		}
+
+		data, err := json.Marshal(&restrictions)
+		assert.NilError(t, err)
+		var fromJSON WordRestrictions
+		assert.NilError(t, json.Unmarshal(data, &fromJSON))
+		assertSameRestrictions(t, &fromJSON, &restrictions, words)
+
+		data, err = restrictions.MarshalBinary()
+		assert.NilError(t, err)
+		var fromBinary WordRestrictions
+		assert.NilError(t, fromBinary.UnmarshalBinary(data))
+		assertSameRestrictions(t, &fromBinary, &restrictions, words)
+
+		// The decoded restrictions can still be updated.
+		result, err := GetResultForGuess(objective, words.At(rng.Intn(words.Len())))
+		assert.NilError(t, err)
+		assert.NilError(t, restrictions.Update(&result))
+		assert.NilError(t, fromBinary.Update(&result))
+		assertSameRestrictions(t, &fromBinary, &restrictions, words)
+	}
+}
+
+func TestWordRestrictionsUnmarshalJSONInvalid(t *testing.T) {
+	tests := []struct {
+		data    string
+		wantErr string
+	}{
+		{`{"word_length":0}`, "word length"},
+		{`{"word_length":3,"present_letters":[{"letter":"ab","min_count":1}]}`, "single letter"},
+		{`{"word_length":3,"present_letters":[{"letter":"a","known_locations":[3],"min_count":1}]}`, "out of range"},
+		{`{"word_length":3,"present_letters":[{"letter":"a","known_locations":[1],"excluded_locations":[1],"min_count":1}]}`, "both known and excluded"},
+		{`{"word_length":3,"present_letters":[{"letter":"a","known_locations":[0,1],"min_count":1}]}`, "minimum count"},
+		{`{"word_length":3,"present_letters":[{"letter":"a","min_count":0}]}`, "minimum count"},
+		{`{"word_length":3,"present_letters":[{"letter":"a","excluded_locations":[0,1],"min_count":1,"exact_count":2}]}`, "exact count"},
+		{`{"word_length":3,"present_letters":[{"letter":"a","known_locations":[0],"min_count":1},{"letter":"b","known_locations":[0],"min_count":1}]}`, "Multiple letters"},
+		{`{"word_length":3,"present_letters":[{"letter":"a","min_count":1},{"letter":"a","min_count":1}]}`, "more than once"},
+		{`{"word_length":3,"present_letters":[{"letter":"a","min_count":1}],"not_present_letters":"a"}`, "both present and not present"},
+	}
+
+	for _, test := range tests {
+		var restrictions WordRestrictions
+		assert.ErrorContains(t, json.Unmarshal([]byte(test.data), &restrictions), test.wantErr, "data: %s", test.data)
+	}
+}
+
+func TestWordRestrictionsUnmarshalBinaryInvalid(t *testing.T) {
+	restrictions := restrictionsForGuesses(t, "xrxae", "aster")
+	data, err := restrictions.MarshalBinary()
+	assert.NilError(t, err)
+
+	var decoded WordRestrictions
+	for i := 0; i < len(data); i++ {
+		assert.ErrorContains(t, decoded.UnmarshalBinary(data[:i]), "Invalid", "length: %v", i)
+	}
+	assert.ErrorContains(t, decoded.UnmarshalBinary(append(data, 0)), "trailing")
+}
+
+func TestGuessResultJSON(t *testing.T) {
+	result := GuessResult{
+		WordFromString("abbey"),
+		[]LetterResult{LetterResultCorrect, LetterResultPresentNotHere, LetterResultNotPresent, LetterResultNotPresent, LetterResultCorrect},
+	}
+
+	data, err := json.Marshal(result)
+	assert.NilError(t, err)
+
+	assert.Equal(t, string(data), `{"guess":"abbey","results":"gy--g"}`)
+	var decoded GuessResult
+	assert.NilError(t, json.Unmarshal(data, &decoded))
+	assert.Assert(t, decoded.Guess.Equal(result.Guess))
+	assert.DeepEqual(t, decoded.Results, result.Results)
+
+	assert.ErrorContains(t, json.Unmarshal([]byte(`{"guess":"abbey","results":"gy-"}`), &decoded), "same length")
+	assert.ErrorContains(t, json.Unmarshal([]byte(`{"guess":"abbey","results":"gy-zz"}`), &decoded), "")
+}
+
+func TestGuessResultBinary(t *testing.T) {
+	result := GuessResult{
+		WordFromString("über"),
+		[]LetterResult{LetterResultUnknown, LetterResultPresentNotHere, LetterResultNotPresent, LetterResultCorrect},
+	}
+
+	data, err := result.MarshalBinary()
+	assert.NilError(t, err)
+
+	// 1 byte of length, 5 bytes of UTF-8, and 1 byte of packed results.
+	assert.Equal(t, len(data), 7)
+	var decoded GuessResult
+	assert.NilError(t, decoded.UnmarshalBinary(data))
+	assert.Assert(t, decoded.Guess.Equal(result.Guess))
+	assert.DeepEqual(t, decoded.Results, result.Results)
+
+	_, err = GuessResult{WordFromString("abc"), []LetterResult{LetterResultCorrect}}.MarshalBinary()
+	assert.ErrorContains(t, err, "same length")
+}
+
+func TestGameResultRoundTrip(t *testing.T) {
+	result := GameResult{
+		Status: GameSuccess,
+		Turns: []TurnData{
+			{WordFromString("aster"), 1000},
+			{WordFromString("lrace"), 12},
+			{WordFromString("grade"), 1},
+		},
+	}
+
+	jsonData, err := json.Marshal(result)
+	assert.NilError(t, err)
+	binaryData, err := result.MarshalBinary()
+	assert.NilError(t, err)
+
+	assert.Equal(t, string(jsonData), `{"status":"success","turns":[`+
+		`{"guess":"aster","num_possible_words_before_guess":1000},`+
+		`{"guess":"lrace","num_possible_words_before_guess":12},`+
+		`{"guess":"grade","num_possible_words_before_guess":1}]}`)
+	for _, decode := range []func(*GameResult) error{
+		func(decoded *GameResult) error { return json.Unmarshal(jsonData, decoded) },
+		func(decoded *GameResult) error { return decoded.UnmarshalBinary(binaryData) },
+	} {
+		var decoded GameResult
+		assert.NilError(t, decode(&decoded))
+		assert.Equal(t, decoded.Status, result.Status)
+		assert.Equal(t, len(decoded.Turns), len(result.Turns))
+		for i, turn := range decoded.Turns {
+			assert.Equal(t, turn.Guess.String(), result.Turns[i].Guess.String())
+			assert.Equal(t, turn.NumPossibleWordsBeforeGuess, result.Turns[i].NumPossibleWordsBeforeGuess)
+		}
+	}
+}
+
+func TestGameStatusUnmarshalTextInvalid(t *testing.T) {
+	var status GameStatus
+	assert.ErrorContains(t, json.Unmarshal([]byte(`"won"`), &status), "Invalid game status")
+	_, err := json.Marshal(GameStatus(7))
+	assert.ErrorContains(t, err, "invalid GameStatus")
+}
@@ -0,0 +1,187 @@
+package go_wordle_solver
+
+import (
+	"regexp"
+	"strings"
+	"unicode/utf8"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+// LetterCount requires a letter to appear in a word between Min and Max times, inclusive.
+type LetterCount struct {
+	Letter rune
+	Min    uint8
+	Max    uint8
+}
+
+// WordMatcher matches words using a regular expression and a set of letter counts, which together
+// are equivalent to a [WordRestrictions].
+//
+// The regular expression only uses per-location character classes, so that it can be used with
+// most regular expression engines, such as grep's. The letter counts express the remaining
+// restrictions, which would otherwise require lookaheads.
+type WordMatcher struct {
+	// Matches the letters that are allowed at each location. This only matches words of the
+	// correct length.
+	Regexp *regexp.Regexp
+	// The number of times that letters must appear in the word, in order of letter.
+	Counts []LetterCount
+	// Whether each letter must appear at most once.
+	NoRepeatedLetters bool
+}
+
+// Matcher returns a [WordMatcher] that matches exactly the words that satisfy these restrictions.
+func (self *WordRestrictions) Matcher() WordMatcher {
+	var sb strings.Builder
+	sb.WriteRune('^')
+	for location := uint8(0); location < self.wordLength; location++ {
+		if letter, isKnown := self.knownLetterAt(location); isKnown {
+			sb.WriteString(regexp.QuoteMeta(string(letter)))
+			continue
+		}
+		excluded := slices.Clone(self.notPresentLetters)
+		for letter, presence := range self.presentLetters {
+			if presence.state(location) == llsNotHere {
+				excluded = append(excluded, letter)
+			}
+		}
+		for letter, locations := range self.excludedLocations {
+			if slices.Contains(locations, location) {
+				excluded = append(excluded, letter)
+			}
+		}
+		if len(excluded) == 0 {
+			sb.WriteRune('.')
+			continue
+		}
+		slices.Sort(excluded)
+		sb.WriteString("[^")
+		for _, letter := range excluded {
+			writeClassLetter(&sb, letter)
+		}
+		sb.WriteRune(']')
+	}
+	sb.WriteRune('$')
+
+	letters := maps.Keys(self.presentLetters)
+	for letter := range self.maxCounts {
+		if _, isPresent := self.presentLetters[letter]; !isPresent {
+			letters = append(letters, letter)
+		}
+	}
+	slices.Sort(letters)
+	counts := make([]LetterCount, 0, len(letters))
+	for _, letter := range letters {
+		var numHere uint8
+		count := LetterCount{letter, 0, self.wordLength}
+		if presence, isPresent := self.presentLetters[letter]; isPresent {
+			numHere = presence.numHere
+			count.Min = presence.minCount
+			if presence.maybeRequiredCount.HasValue() {
+				count.Min = presence.maybeRequiredCount.Value()
+				count.Max = count.Min
+			}
+		}
+		if maxCount, hasMax := self.maxCounts[letter]; hasMax && maxCount < count.Max {
+			count.Max = maxCount
+		}
+		if count.Min > numHere || count.Max < self.wordLength {
+			// Otherwise the regexp already guarantees this count.
+			counts = append(counts, count)
+		}
+	}
+	return WordMatcher{regexp.MustCompile(sb.String()), counts, self.noRepeatedLetters}
+}
+
+// knownLetterAt returns the letter that must be at the given location, if known.
+func (self *WordRestrictions) knownLetterAt(location uint8) (rune, bool) {
+	for _, letter := range self.sortedPresentLetters() {
+		if self.presentLetters[letter].state(location) == llsHere {
+			return letter, true
+		}
+	}
+	return 0, false
+}
+
+// writeClassLetter writes the letter so that it can be used within a character class.
+func writeClassLetter(sb *strings.Builder, letter rune) {
+	switch letter {
+	case '\\', ']', '[', '^', '-':
+		sb.WriteRune('\\')
+	}
+	sb.WriteRune(letter)
+}
+
+// MatchString returns true iff the given string matches both the regexp and the letter counts, and
+// doesn't repeat any letters if [WordMatcher.NoRepeatedLetters] is set.
+func (m *WordMatcher) MatchString(s string) bool {
+	if !m.Regexp.MatchString(s) {
+		return false
+	}
+	for _, count := range m.Counts {
+		n := strings.Count(s, string(count.Letter))
+		if n < int(count.Min) || n > int(count.Max) {
+			return false
+		}
+	}
+	if m.NoRepeatedLetters {
+		for i, letter := range s {
+			if strings.ContainsRune(s[i+utf8.RuneLen(letter):], letter) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// Match returns true iff the given word matches, as in [WordMatcher.MatchString].
+func (m *WordMatcher) Match(word Word) bool {
+	return m.MatchString(word.String())
+}
+
+// Pattern returns a compact, crossword-style pattern describing the restrictions, such as:
+//
+//	?r??e +a -lst
+//
+// Each location shows either the known letter or '?'. The '+' section lists letters that must
+// appear somewhere else in the word, repeated as many times as they must appear. The '-' section
+// lists letters that are not in the word. Empty sections are omitted.
+//
+// Unlike [WordRestrictions.Matcher], this does not describe which locations each letter is
+// excluded from, nor maximum letter counts, nor whether letters may repeat.
+func (self *WordRestrictions) Pattern() string {
+	var sb strings.Builder
+	for location := uint8(0); location < self.wordLength; location++ {
+		if letter, isKnown := self.knownLetterAt(location); isKnown {
+			sb.WriteRune(letter)
+		} else {
+			sb.WriteRune('?')
+		}
+	}
+	letters := maps.Keys(self.presentLetters)
+	slices.Sort(letters)
+	var required strings.Builder
+	for _, letter := range letters {
+		presence := self.presentLetters[letter]
+		minCount := presence.minCount
+		if presence.maybeRequiredCount.HasValue() {
+			minCount = presence.maybeRequiredCount.Value()
+		}
+		for i := presence.numHere; i < minCount; i++ {
+			required.WriteRune(letter)
+		}
+	}
+	if required.Len() > 0 {
+		sb.WriteString(" +")
+		sb.WriteString(required.String())
+	}
+	if len(self.notPresentLetters) > 0 {
+		notPresent := slices.Clone(self.notPresentLetters)
+		slices.Sort(notPresent)
+		sb.WriteString(" -")
+		sb.WriteString(string(notPresent))
+	}
+	return sb.String()
+}
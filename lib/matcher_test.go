@@ -0,0 +1,85 @@
+package go_wordle_solver
+
+import (
+	"math/rand"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestWordRestrictionsMatcher(t *testing.T) {
+	restrictions := restrictionsForGuesses(t, "xrxae", "aster", "lrtce")
+
+	matcher := restrictions.Matcher()
+
+	assert.Equal(t, matcher.Regexp.String(), "^[^aclst]r[^clst][^celst]e$")
+	assert.DeepEqual(t, matcher.Counts, []LetterCount{{'a', 1, 5}})
+	assert.Assert(t, matcher.MatchString("drake"))
+	assert.Assert(t, matcher.Match(WordFromString("xrxae")))
+	assert.Assert(t, !matcher.MatchString("drive"))
+	assert.Assert(t, !matcher.MatchString("xraee"))
+	assert.Assert(t, !matcher.MatchString("xrxaed"))
+}
+
+func TestWordRestrictionsMatcherEmpty(t *testing.T) {
+	restrictions := InitWordRestrictions(3)
+
+	matcher := restrictions.Matcher()
+
+	assert.Equal(t, matcher.Regexp.String(), "^...$")
+	assert.Equal(t, len(matcher.Counts), 0)
+	assert.Assert(t, matcher.MatchString("abc"))
+	assert.Assert(t, matcher.MatchString("ébc"))
+	assert.Assert(t, !matcher.MatchString("ab"))
+	assert.Equal(t, restrictions.Pattern(), "???")
+}
+
+func TestWordRestrictionsMatcherEscapesLetters(t *testing.T) {
+	restrictions := restrictionsForGuesses(t, "a]b", "^-]")
+
+	matcher := restrictions.Matcher()
+
+	assert.Equal(t, matcher.Regexp.String(), `^[^\-\^][^\-\^][^\-\]\^]$`)
+	assert.Assert(t, matcher.MatchString("a]b"))
+	assert.Assert(t, !matcher.MatchString("a-]"))
+}
+
+func TestWordRestrictionsPattern(t *testing.T) {
+	tests := []struct {
+		objective string
+		guesses   []string
+		want      string
+	}{
+		{"xrxae", []string{"aster", "lrtce"}, "?r??e +a -clst"},
+		{"aabcd", []string{"xxaax"}, "????? +aa -x"},
+		{"abcde", []string{"abcde"}, "abcde"},
+	}
+	for _, test := range tests {
+		restrictions := restrictionsForGuesses(t, test.objective, test.guesses...)
+		assert.Equal(t, restrictions.Pattern(), test.want)
+	}
+}
+
+func TestWordRestrictionsMatcherEqualsIsSatisfiedBy(t *testing.T) {
+	bank, err := BuiltinWordBank("1000-wordle-shuffled")
+	assert.NilError(t, err)
+	words := bank.Words()
+	rng := rand.New(rand.NewSource(37))
+
+	for trial := 0; trial < 100; trial++ {
+		objective := words.At(rng.Intn(words.Len()))
+		restrictions := InitWordRestrictions(bank.WordLength())
+		numGuesses := 1 + rng.Intn(4)
+		for i := 0; i < numGuesses; i++ {
+			result, err := GetResultForGuess(objective, words.At(rng.Intn(words.Len())))
+			assert.NilError(t, err)
+			assert.NilError(t, restrictions.Update(&result))
+
+			matcher := restrictions.Matcher()
+			for j := 0; j < words.Len(); j++ {
+				word := words.At(j)
+				assert.Equal(t, matcher.Match(word), restrictions.IsSatisfiedBy(word), "word: %s, objective: %s, pattern: %s, matcher: %v", word, objective, restrictions.Pattern(), matcher)
+			}
+		}
+	}
+}
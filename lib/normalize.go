@@ -0,0 +1,178 @@
+package go_wordle_solver
+
+import (
+	"strings"
+	"sync"
+	"unicode"
+
+	"golang.org/x/text/cases"
+	"golang.org/x/text/language"
+	"golang.org/x/text/unicode/norm"
+)
+
+// NormalizerOptions configures how a [Normalizer] converts strings into words.
+type NormalizerOptions struct {
+	// The locale to use for converting words to lower case, as a BCP 47 language tag. For example,
+	// with "tr" (Turkish), "I" is lower-cased to "ı" instead of "i".
+	//
+	// If empty, the default Unicode case mapping is used.
+	Locale string
+	// Whether to remove accents and other diacritics from letters, e.g. "é" becomes "e".
+	FoldAccents bool
+	// Letters that should keep their accents when FoldAccents is true, e.g. "ñ" for Spanish. These
+	// are converted to lower case with the locale, so "Ñ" works too.
+	KeepLetters string
+}
+
+// Normalizer converts strings into [Word]s in a consistent form, so that words that look the same
+// are always equal.
+//
+// Words are trimmed, converted to lower case, and converted to Unicode normalization form C (NFC),
+// so that precomposed and decomposed letters (e.g. "é" vs "é") produce the same [Word].
+// Accents may optionally be removed entirely.
+//
+// The zero value is ready to use, and uses the default Unicode case mapping without folding
+// accents. Normalizers are safe for concurrent use.
+type Normalizer struct {
+	locale      language.Tag
+	foldAccents bool
+	keepLetters string
+	// Lower-casers for the locale. Casers can't be used concurrently, so they're pooled. If nil,
+	// the default casers are used.
+	casers *sync.Pool
+}
+
+// The lower-casers for the default Unicode case mapping.
+var defaultCasers = newCaserPool(language.Und)
+
+func newCaserPool(locale language.Tag) *sync.Pool {
+	return &sync.Pool{New: func() any {
+		caser := cases.Lower(locale)
+		return &caser
+	}}
+}
+
+// InitNormalizer constructs a [Normalizer] with the given options.
+//
+// Returns an error if the locale is not a valid BCP 47 language tag.
+func InitNormalizer(options NormalizerOptions) (Normalizer, error) {
+	locale := language.Und
+	if options.Locale != "" {
+		var err error
+		locale, err = language.Parse(options.Locale)
+		if err != nil {
+			return Normalizer{}, err
+		}
+	}
+	n := Normalizer{
+		locale:      locale,
+		foldAccents: options.FoldAccents,
+		casers:      newCaserPool(locale),
+	}
+	n.keepLetters = norm.NFC.String(n.toLower(options.KeepLetters))
+	return n, nil
+}
+
+// toLower converts the string to lower case with this normalizer's locale.
+func (n Normalizer) toLower(s string) string {
+	casers := n.casers
+	if casers == nil {
+		casers = defaultCasers
+	}
+	caser := casers.Get().(*cases.Caser)
+	defer casers.Put(caser)
+	return caser.String(s)
+}
+
+// Normalize converts the given string into its normalized form.
+func (n Normalizer) Normalize(s string) string {
+	s = strings.TrimSpace(s)
+	if isASCII(s) && !n.hasSpecialCasing() {
+		// Nothing else can change for ASCII strings.
+		return strings.ToLower(s)
+	}
+	s = norm.NFC.String(n.toLower(s))
+	if !n.foldAccents {
+		return s
+	}
+	var sb strings.Builder
+	sb.Grow(len(s))
+	for _, letter := range s {
+		if letter <= unicode.MaxASCII || strings.ContainsRune(n.keepLetters, letter) {
+			sb.WriteRune(letter)
+			continue
+		}
+		// Decompose the letter, and drop any combining marks.
+		for _, part := range norm.NFD.String(string(letter)) {
+			if !unicode.Is(unicode.Mn, part) {
+				sb.WriteRune(part)
+			}
+		}
+	}
+	return norm.NFC.String(sb.String())
+}
+
+// WordFromString converts the given string into a normalized [Word].
+func (n Normalizer) WordFromString(s string) Word {
+	return WordFromString(n.Normalize(s))
+}
+
+// NormalizeWord converts the given word into its normalized form.
+//
+// This is cheap for words that are already normalized ASCII words.
+func (n Normalizer) NormalizeWord(w Word) Word {
+	if w.isPacked() && !n.hasSpecialCasing() && isNormalizedPacked(w.packed) {
+		return w
+	}
+	return n.WordFromString(w.String())
+}
+
+// normalizeResult returns the result with its guess normalized. The result is only copied if the
+// guess changes.
+func (n Normalizer) normalizeResult(result *GuessResult) *GuessResult {
+	guess := n.NormalizeWord(result.Guess)
+	if guess.Equal(result.Guess) {
+		return result
+	}
+	return &GuessResult{Guess: guess, Results: result.Results}
+}
+
+// GetResultForGuess determines the result of the given guess when applied to the given objective,
+// after normalizing both words.
+//
+// The returned result contains the normalized guess.
+func (n Normalizer) GetResultForGuess(objective, guess Word) (GuessResult, error) {
+	return GetResultForGuess(n.WordFromString(objective.String()), n.WordFromString(guess.String()))
+}
+
+// hasSpecialCasing returns true if this normalizer's locale lower-cases some ASCII letters
+// differently from the default.
+func (n Normalizer) hasSpecialCasing() bool {
+	base, _ := n.locale.Base()
+	switch base.String() {
+	case "tr", "az":
+		return true
+	}
+	return false
+}
+
+// isNormalizedPacked returns true if the packed word has no upper-case letters or spaces, so the
+// default normalization wouldn't change it.
+func isNormalizedPacked(p packedWord) bool {
+	for i := 0; i < p.len(); i++ {
+		letter := p.at(i)
+		if (letter >= 'A' && letter <= 'Z') || unicode.IsSpace(rune(letter)) {
+			return false
+		}
+	}
+	return true
+}
+
+func isASCII(s string) bool {
+	for i := 0; i < len(s); i++ {
+		if s[i] > unicode.MaxASCII {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,176 @@
+package go_wordle_solver
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func ExampleNormalizer() {
+	normalizer, err := InitNormalizer(NormalizerOptions{Locale: "es", FoldAccents: true, KeepLetters: "ñ"})
+	if err != nil {
+		fmt.Println(err)
+		return
+	}
+
+	fmt.Println(normalizer.Normalize(" Canción "))
+	fmt.Println(normalizer.Normalize("NIÑO"))
+
+	// Output:
+	// cancion
+	// niño
+}
+
+func TestNormalizerDefault(t *testing.T) {
+	var normalizer Normalizer
+
+	assert.Equal(t, normalizer.Normalize(" HeLLo "), "hello")
+	assert.Equal(t, normalizer.Normalize("CAFÉ"), "café")
+	// Decomposed letters are composed.
+	assert.Equal(t, normalizer.Normalize("cafe\u0301"), "café")
+	// Accents are kept by default.
+	assert.Assert(t, !normalizer.WordFromString("café").Equal(normalizer.WordFromString("cafe")))
+	assert.Assert(t, normalizer.WordFromString("café").Equal(normalizer.WordFromString("cafe\u0301")))
+	assert.Equal(t, normalizer.WordFromString("cafe\u0301").Len(), 4)
+}
+
+func TestNormalizerFoldAccents(t *testing.T) {
+	normalizer, err := InitNormalizer(NormalizerOptions{FoldAccents: true})
+	assert.NilError(t, err)
+
+	assert.Equal(t, normalizer.Normalize("Éclair"), "eclair")
+	assert.Equal(t, normalizer.Normalize("cafe\u0301"), "cafe")
+	assert.Equal(t, normalizer.Normalize("niño"), "nino")
+	assert.Equal(t, normalizer.Normalize("straße"), "straße")
+}
+
+func TestNormalizerFoldAccentsWithKeepLetters(t *testing.T) {
+	normalizer, err := InitNormalizer(NormalizerOptions{FoldAccents: true, KeepLetters: "ñ"})
+	assert.NilError(t, err)
+
+	assert.Equal(t, normalizer.Normalize("Niño"), "niño")
+	assert.Equal(t, normalizer.Normalize("nin\u0303o"), "niño")
+	assert.Equal(t, normalizer.Normalize("pingüino"), "pinguino")
+}
+
+func TestNormalizerKeepLettersIgnoresCase(t *testing.T) {
+	normalizer, err := InitNormalizer(NormalizerOptions{FoldAccents: true, KeepLetters: "Ñ"})
+	assert.NilError(t, err)
+
+	assert.Equal(t, normalizer.Normalize("NIÑO"), "niño")
+}
+
+func TestNormalizerIsSafeForConcurrentUse(t *testing.T) {
+	normalizer, err := InitNormalizer(NormalizerOptions{Locale: "tr"})
+	assert.NilError(t, err)
+	results := make(chan string)
+
+	for i := 0; i < 4; i++ {
+		go func() {
+			results <- normalizer.Normalize("KIŞ")
+		}()
+	}
+
+	for i := 0; i < 4; i++ {
+		assert.Equal(t, <-results, "kış")
+	}
+}
+
+func TestNormalizerNormalizeWord(t *testing.T) {
+	var normalizer Normalizer
+	word := WordFromString("crane")
+
+	assert.Assert(t, normalizer.NormalizeWord(word).Equal(word))
+	assert.Equal(t, normalizer.NormalizeWord(WordFromString("CRANE")).String(), "crane")
+	assert.Equal(t, normalizer.NormalizeWord(WordFromString(" crane")).String(), "crane")
+	assert.Equal(t, normalizer.NormalizeWord(WordFromString("CAFÉ")).String(), "café")
+}
+
+func TestPossibleWordsNormalizeGuesses(t *testing.T) {
+	normalizer, err := InitNormalizer(NormalizerOptions{FoldAccents: true})
+	assert.NilError(t, err)
+	bank, err := WordBankFromSliceWithNormalizer([]string{"cafe", "cage", "face"}, normalizer)
+	assert.NilError(t, err)
+	words := bank.Words()
+	result := guessResult(t, "CAFÉ", "ggg-")
+
+	assert.NilError(t, words.Filter(&result))
+
+	assert.Equal(t, words.Len(), 0)
+	assert.Equal(t, result.Guess.String(), "CAFÉ")
+	words = bank.Words()
+	assert.Assert(t, words.Remove(WordFromString("Café")))
+	assert.Equal(t, words.Len(), 2)
+}
+
+func TestPlayGameNormalizesObjective(t *testing.T) {
+	bank, err := WordBankFromSlice([]string{"crane", "slate", "trace"})
+	assert.NilError(t, err)
+	guesser := InitRandomGuesser(&bank)
+
+	result, err := PlayGameWithGuesser(WordFromString("TRACE"), 10, &guesser)
+
+	assert.NilError(t, err)
+	assert.Equal(t, result.Status, GameSuccess)
+	assert.Equal(t, result.Turns[len(result.Turns)-1].Guess.String(), "trace")
+}
+
+func TestNormalizerTurkishCasing(t *testing.T) {
+	normalizer, err := InitNormalizer(NormalizerOptions{Locale: "tr"})
+	assert.NilError(t, err)
+
+	assert.Equal(t, normalizer.Normalize("KIŞI"), "kışı")
+	assert.Equal(t, normalizer.Normalize("İKİ"), "iki")
+	assert.Equal(t, normalizer.Normalize("IRMAK"), "ırmak")
+
+	var defaultNormalizer Normalizer
+	assert.Equal(t, defaultNormalizer.Normalize("IRMAK"), "irmak")
+}
+
+func TestInitNormalizerWithInvalidLocale(t *testing.T) {
+	_, err := InitNormalizer(NormalizerOptions{Locale: "not a locale"})
+	assert.ErrorContains(t, err, "not well-formed")
+}
+
+func TestNormalizerGetResultForGuess(t *testing.T) {
+	var normalizer Normalizer
+
+	result, err := normalizer.GetResultForGuess(WordFromString("café"), WordFromString("CAFE\u0301"))
+
+	assert.NilError(t, err)
+	assert.DeepEqual(t, result, GuessResult{
+		Guess: WordFromString("café"),
+		Results: []LetterResult{
+			LetterResultCorrect,
+			LetterResultCorrect,
+			LetterResultCorrect,
+			LetterResultCorrect,
+		},
+	})
+}
+
+func TestWordBankFromReaderWithNormalizer(t *testing.T) {
+	normalizer, err := InitNormalizer(NormalizerOptions{FoldAccents: true})
+	assert.NilError(t, err)
+
+	bank, err := WordBankFromReaderWithNormalizer(strings.NewReader("Café\ncafe\u0301\nÉTÉS"), normalizer)
+
+	assert.NilError(t, err)
+	assert.Equal(t, bank.WordLength(), uint8(4))
+	pw := bank.Words()
+	assert.DeepEqual(t, pw.At(0), WordFromString("cafe"))
+	assert.DeepEqual(t, pw.At(1), WordFromString("cafe"))
+	assert.DeepEqual(t, pw.At(2), WordFromString("etes"))
+	assert.Equal(t, bank.Normalizer(), normalizer)
+}
+
+func TestWordBankFromReaderNormalizesByDefault(t *testing.T) {
+	bank, err := WordBankFromReader(strings.NewReader("Café\ncafe\u0301"))
+
+	assert.NilError(t, err)
+	assert.Equal(t, bank.WordLength(), uint8(4))
+	pw := bank.Words()
+	assert.DeepEqual(t, pw.At(0), pw.At(1))
+}
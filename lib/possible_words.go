@@ -1,20 +1,29 @@
 package go_wordle_solver
 
-import "golang.org/x/exp/slices"
+import (
+	"fmt"
+
+	"golang.org/x/exp/slices"
+)
 
 // PossibleWords provides a list of possible words for a Wordle puzzle.
 //
 // It provides easy operations to access words and to filter the list based on a [GuessResult].
-// PossibleWords can be retrieved from a [WordBank].
+// PossibleWords can be retrieved from a [WordBank], and filter with that bank's [FeedbackRule].
+// Guesses given to them are normalized with the bank's [Normalizer].
 type PossibleWords struct {
 	words        []Word
 	restrictions WordRestrictions
+	rule         FeedbackRule
+	normalizer   Normalizer
 }
 
-func initPossibleWords(words []Word) PossibleWords {
+func initPossibleWords(words []Word, rule FeedbackRule) PossibleWords {
 	return PossibleWords{
 		slices.Clone(words),
 		InitWordRestrictions(uint8(words[0].Len())),
+		rule,
+		Normalizer{},
 	}
 }
 
@@ -22,8 +31,23 @@ func initPossibleWords(words []Word) PossibleWords {
 func (pw *PossibleWords) Copy() PossibleWords {
 	return PossibleWords{
 		slices.Clone(pw.words),
-		pw.restrictions,
+		pw.restrictions.Copy(),
+		pw.rule,
+		pw.normalizer,
+	}
+}
+
+// Normalizer provides the [Normalizer] that guesses are normalized with.
+func (pw *PossibleWords) Normalizer() Normalizer {
+	return pw.normalizer
+}
+
+// FeedbackRule provides the rule that these words are filtered with.
+func (pw *PossibleWords) FeedbackRule() FeedbackRule {
+	if pw.rule == nil {
+		return WordleRule{}
 	}
+	return pw.rule
 }
 
 // Len returns the number of possible words.
@@ -41,9 +65,19 @@ func (pw *PossibleWords) At(i int) Word {
 
 // Filter filters the possible words based on the given [GuessResult].
 //
-// Results from multiple calls to this method are accumulated to filter as many words as possible.
-// If results conflict, an error is returned.
+// The guess is normalized first. Results from multiple calls to this method are accumulated to
+// filter as many words as possible. If results conflict, an error is returned and the possible
+// words are unchanged. See [WordRestrictions.Update].
+//
+// With any rule other than [WordleRule], this keeps the words that would give exactly the same
+// feedback. The guess itself is always removed, since feedback is only given for incorrect
+// guesses. This returns an error if the result is the wrong length, or if no words would give it,
+// in which case the possible words are unchanged.
 func (pw *PossibleWords) Filter(gr *GuessResult) error {
+	gr = pw.normalizer.normalizeResult(gr)
+	if _, isWordle := pw.FeedbackRule().(WordleRule); !isWordle {
+		return pw.filterByFeedback(gr)
+	}
 	err := pw.restrictions.Update(gr)
 	if err != nil {
 		return err
@@ -52,10 +86,35 @@ func (pw *PossibleWords) Filter(gr *GuessResult) error {
 	return nil
 }
 
+// filterByFeedback keeps the words other than the guess that would give the same feedback as the
+// given result.
+//
+// If no words would give this result, an error is returned and the words are unchanged.
+func (pw *PossibleWords) filterByFeedback(gr *GuessResult) error {
+	wordLength := int(pw.restrictions.wordLength)
+	if gr.Guess.Len() != wordLength || len(gr.Results) != wordLength {
+		return fmt.Errorf("The guess (%s) and results must have %v letters.", gr.Guess, wordLength)
+	}
+	rule := pw.FeedbackRule()
+	matches := func(w Word) bool {
+		if w.Equal(gr.Guess) {
+			return false
+		}
+		feedback, err := rule.Feedback(w, gr.Guess)
+		return err == nil && slices.Equal(feedback.Results, gr.Results)
+	}
+	if !anyValue(pw.words, matches) {
+		return fmt.Errorf("No words would give the result %s for %s.", LetterResultsString(gr.Results), gr.Guess)
+	}
+	pw.words = filter(pw.words, matches)
+	return nil
+}
+
 // Remove deletes the given word, if present.
 //
 // Returns true if the word was previously present and has now been removed.
 func (pw *PossibleWords) Remove(w Word) bool {
+	w = pw.normalizer.NormalizeWord(w)
 	i := slices.IndexFunc(pw.words, w.Equal)
 	if i >= 0 {
 		pw.words = slices.Delete(pw.words, i, i+1)
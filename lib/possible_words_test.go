@@ -1,13 +1,14 @@
 package go_wordle_solver
 
 import (
+	"math/rand"
 	"testing"
 
 	"gotest.tools/v3/assert"
 )
 
 func TestPossibleWordsLen(t *testing.T) {
-	pw := initPossibleWords([]Word{WordFromString("foo"), WordFromString("bar")})
+	pw := initPossibleWords([]Word{WordFromString("foo"), WordFromString("bar")}, WordleRule{})
 	assert.Equal(t, pw.Len(), 2)
 
 	var pwPointer *PossibleWords = nil
@@ -15,7 +16,7 @@ func TestPossibleWordsLen(t *testing.T) {
 }
 
 func TestPossibleWordsAt(t *testing.T) {
-	pw := initPossibleWords([]Word{WordFromString("foo"), WordFromString("bar")})
+	pw := initPossibleWords([]Word{WordFromString("foo"), WordFromString("bar")}, WordleRule{})
 
 	assert.DeepEqual(t, pw.At(0), WordFromString("foo"))
 	assert.DeepEqual(t, pw.At(1), WordFromString("bar"))
@@ -27,7 +28,7 @@ func TestPossibleWordsFilter(t *testing.T) {
 		WordFromString("bad"),
 		WordFromString("and"),
 		WordFromString("cat"),
-	})
+	}, WordleRule{})
 
 	gr, _ := GetResultForGuess(WordFromString("mad"), WordFromString("add"))
 	pw.Filter(&gr)
@@ -38,7 +39,7 @@ func TestPossibleWordsFilter(t *testing.T) {
 }
 
 func TestPossibleWordsRemove(t *testing.T) {
-	pw := initPossibleWords([]Word{WordFromString("foo"), WordFromString("bar"), WordFromString("baz")})
+	pw := initPossibleWords([]Word{WordFromString("foo"), WordFromString("bar"), WordFromString("baz")}, WordleRule{})
 
 	assert.Assert(t, !pw.Remove(WordFromString("zzz")))
 	assert.Equal(t, pw.Len(), 3)
@@ -50,7 +51,7 @@ func TestPossibleWordsRemove(t *testing.T) {
 }
 
 func TestPossibleWordsMaximizing(t *testing.T) {
-	pw := initPossibleWords([]Word{WordFromString("aaa"), WordFromString("aba"), WordFromString("bbb"), WordFromString("cbc")})
+	pw := initPossibleWords([]Word{WordFromString("aaa"), WordFromString("aba"), WordFromString("bbb"), WordFromString("cbc")}, WordleRule{})
 
 	mostBs := func(w Word) int64 {
 		var sum int64 = 0
@@ -64,3 +65,98 @@ func TestPossibleWordsMaximizing(t *testing.T) {
 
 	assert.DeepEqual(t, pw.Maximizing(mostBs), WordFromString("bbb"))
 }
+
+// checkFilterMatchesFeedback filters the words with the feedback for each guess, and asserts that
+// the remaining words are exactly those that would give the same feedback.
+func checkFilterMatchesFeedback(t *testing.T, words PossibleWords, objective Word, guesses ...Word) {
+	t.Helper()
+	all := words.Copy()
+	var results []GuessResult
+	for _, guess := range guesses {
+		result, err := GetResultForGuess(objective, guess)
+		assert.NilError(t, err)
+		results = append(results, result)
+
+		assert.NilError(t, words.Filter(&result))
+
+		var want []string
+		for i := 0; i < all.Len(); i++ {
+			if hasSameFeedback(all.At(i), results) {
+				want = append(want, all.At(i).String())
+			}
+		}
+		var got []string
+		for i := 0; i < words.Len(); i++ {
+			got = append(got, words.At(i).String())
+		}
+		assert.DeepEqual(t, got, want)
+	}
+}
+
+// hasSameFeedback returns true iff each result is the feedback for its guess against the given
+// objective.
+func hasSameFeedback(objective Word, results []GuessResult) bool {
+	for _, result := range results {
+		feedback, _ := GetResultForGuess(objective, result.Guess)
+		if LetterResultsString(feedback.Results) != LetterResultsString(result.Results) {
+			return false
+		}
+	}
+	return true
+}
+
+// allWords returns every word of the given length using the given letters.
+func allWords(letters string, length int) []Word {
+	words := []string{""}
+	for i := 0; i < length; i++ {
+		var longer []string
+		for _, word := range words {
+			for _, letter := range letters {
+				longer = append(longer, word+string(letter))
+			}
+		}
+		words = longer
+	}
+	result := make([]Word, len(words))
+	for i, word := range words {
+		result[i] = WordFromString(word)
+	}
+	return result
+}
+
+func FuzzPossibleWordsFilterMatchesFeedback(f *testing.F) {
+	const length = 4
+	f.Add([]byte("abba"), []byte("babbaaaa"))
+	f.Add([]byte("abcd"), []byte("ddddcbad"))
+	f.Add([]byte("eeee"), []byte("aeeaeaae"))
+	words := initPossibleWords(allWords("abcde", length), WordleRule{})
+	f.Fuzz(func(t *testing.T, objectiveData, guessesData []byte) {
+		if len(objectiveData) != length {
+			t.Skip()
+		}
+		objective := WordFromString(fuzzWord(objectiveData))
+		var guesses []Word
+		for i := 0; i+length <= len(guessesData) && len(guesses) < 6; i += length {
+			guesses = append(guesses, WordFromString(fuzzWord(guessesData[i:i+length])))
+		}
+
+		checkFilterMatchesFeedback(t, words.Copy(), objective, guesses...)
+	})
+}
+
+func TestPossibleWordsFilterMatchesFeedbackForWordList(t *testing.T) {
+	bank, err := BuiltinWordBank("1000-wordle-shuffled")
+	assert.NilError(t, err)
+	words := bank.Words()
+	rng := rand.New(rand.NewSource(1))
+
+	for n := 0; n < 50; n++ {
+		objective := words.At(rng.Intn(words.Len()))
+		guesses := make([]Word, rng.Intn(4)+1)
+		for i := range guesses {
+			guesses[i] = words.At(rng.Intn(words.Len()))
+		}
+
+		checkFilterMatchesFeedback(t, words.Copy(), objective, guesses...)
+	}
+}
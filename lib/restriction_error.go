@@ -0,0 +1,105 @@
+package go_wordle_solver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// RestrictionError describes why new restrictions conflict with a [WordRestrictions].
+//
+// Each update to a [WordRestrictions] is a turn, whether it's from a call to
+// [WordRestrictions.Update] or to a method that adds restrictions directly, such as
+// [WordRestrictions.SetLetterAt].
+type RestrictionError struct {
+	// The letter whose restrictions conflict, or 0 if the conflict isn't specific to one letter.
+	Letter rune
+	// The location where the restrictions conflict, starting from 0, if the conflict is specific to
+	// one location.
+	Location Optional[uint8]
+	// The result that couldn't be added, or nil if the new restrictions were added directly.
+	Result *GuessResult
+	// The earliest turn that, together with the turns before it, conflicts with the new
+	// restrictions, if known. Turns start from 0. This is empty if the new restrictions are
+	// inconsistent by themselves, or if the restrictions were decoded rather than built turn by
+	// turn.
+	ConflictingTurn Optional[int]
+	// The result from the conflicting turn, or nil if that turn added restrictions directly or if
+	// the turn isn't known.
+	ConflictingResult *GuessResult
+	// Why the restrictions conflict.
+	Reason string
+}
+
+func newRestrictionError(letter rune, location Optional[uint8], reason error) *RestrictionError {
+	var err *RestrictionError
+	if errors.As(reason, &err) {
+		// Keep the more specific details.
+		return err
+	}
+	return &RestrictionError{
+		Letter:   letter,
+		Location: location,
+		Reason:   reason.Error(),
+	}
+}
+
+// Error describes the conflict, such as:
+//
+//	The result for arise conflicts with turn 1 (crane) for the letter r at position 2. <Reason>
+//
+// Turns and positions start from 1.
+func (e *RestrictionError) Error() string {
+	var sb strings.Builder
+	if e.Result != nil {
+		fmt.Fprintf(&sb, "The result for %s", e.Result.Guess)
+	} else {
+		sb.WriteString("The restriction")
+	}
+	if e.ConflictingTurn.HasValue() {
+		fmt.Fprintf(&sb, " conflicts with turn %v", e.ConflictingTurn.Value()+1)
+		if e.ConflictingResult != nil {
+			fmt.Fprintf(&sb, " (%s)", e.ConflictingResult.Guess)
+		}
+	} else {
+		sb.WriteString(" is inconsistent")
+	}
+	if e.Letter != 0 {
+		fmt.Fprintf(&sb, " for the letter %c", e.Letter)
+		if e.Location.HasValue() {
+			fmt.Fprintf(&sb, " at position %v", e.Location.Value()+1)
+		}
+	}
+	fmt.Fprintf(&sb, ". %s", e.Reason)
+	return sb.String()
+}
+
+// explain adds the context of the given failed update to its error, if it's a [*RestrictionError].
+func (self *WordRestrictions) explain(update restrictionUpdate, err error) error {
+	var restrictionErr *RestrictionError
+	if !errors.As(err, &restrictionErr) {
+		return err
+	}
+	restrictionErr.Result = update.result
+
+	// Replay the earlier turns to find the first one that conflicts with this update. This is only
+	// done on failure, so it's okay that it's slow.
+	replayed := InitWordRestrictions(self.wordLength)
+	attempt := replayed.Copy()
+	if update.apply(&attempt) != nil {
+		// This update is inconsistent by itself.
+		return restrictionErr
+	}
+	for turn, previous := range self.updates {
+		if previous.apply(&replayed) != nil {
+			break
+		}
+		attempt = replayed.Copy()
+		if update.apply(&attempt) != nil {
+			restrictionErr.ConflictingTurn = OptionalOf(turn)
+			restrictionErr.ConflictingResult = previous.result
+			break
+		}
+	}
+	return restrictionErr
+}
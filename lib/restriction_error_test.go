@@ -0,0 +1,232 @@
+package go_wordle_solver
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func guessResult(t *testing.T, guess, results string) GuessResult {
+	t.Helper()
+	letterResults, err := LetterResultsFromString(results)
+	assert.NilError(t, err)
+	return GuessResult{WordFromString(guess), letterResults}
+}
+
+// updateWithConflict applies the results, expecting only the last to fail, and returns its error.
+func updateWithConflict(t *testing.T, restrictions *WordRestrictions, results ...GuessResult) *RestrictionError {
+	t.Helper()
+	for i := 0; i < len(results)-1; i++ {
+		assert.NilError(t, restrictions.Update(&results[i]))
+	}
+	before := restrictions.Summary().String()
+	err := restrictions.Update(&results[len(results)-1])
+	var restrictionErr *RestrictionError
+	assert.Assert(t, errors.As(err, &restrictionErr), "error: %v", err)
+	// The failed update doesn't change the restrictions.
+	assert.Equal(t, restrictions.Summary().String(), before)
+	return restrictionErr
+}
+
+func TestWordRestrictionsUpdateConflicts(t *testing.T) {
+	tests := []struct {
+		name         string
+		results      []GuessResult
+		wantLetter   rune
+		wantLocation uint8
+		wantTurn     int
+		wantGuess    string
+		wantErr      string
+	}{
+		{
+			name:         "correct letter already not here",
+			results:      []GuessResult{guessResult(t, "abc", "y--"), guessResult(t, "axx", "g--")},
+			wantLetter:   'a',
+			wantLocation: 0,
+			wantTurn:     0,
+			wantGuess:    "abc",
+			wantErr:      "The result for axx conflicts with turn 1 (abc) for the letter a at position 1. Can't set letter to here at index 0 since it's already marked as not here.",
+		},
+		{
+			name:         "present letter already here",
+			results:      []GuessResult{guessResult(t, "xyz", "---"), guessResult(t, "abc", "g--"), guessResult(t, "axx", "y--")},
+			wantLetter:   'a',
+			wantLocation: 0,
+			wantTurn:     1,
+			wantGuess:    "abc",
+			wantErr:      "already marked as here",
+		},
+		{
+			name:         "not present letter already here",
+			results:      []GuessResult{guessResult(t, "abc", "g--"), guessResult(t, "axx", "---")},
+			wantLetter:   'a',
+			wantLocation: 0,
+			wantTurn:     0,
+			wantGuess:    "abc",
+			wantErr:      "already marked as present here",
+		},
+		{
+			name:         "more than the exact count",
+			results:      []GuessResult{guessResult(t, "aaa", "gg-"), guessResult(t, "xya", "--g")},
+			wantLetter:   'a',
+			wantLocation: 2,
+			wantTurn:     0,
+			wantGuess:    "aaa",
+			wantErr:      "already marked as not here",
+		},
+		{
+			name:         "conflicting known letters",
+			results:      []GuessResult{guessResult(t, "abc", "g--"), guessResult(t, "dbc", "g--")},
+			wantLetter:   'd',
+			wantLocation: 0,
+			wantTurn:     0,
+			wantGuess:    "abc",
+			wantErr:      "already marked as here",
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			restrictions := InitWordRestrictions(3)
+
+			err := updateWithConflict(t, &restrictions, test.results...)
+
+			assert.ErrorContains(t, err, test.wantErr)
+			assert.Equal(t, err.Letter, test.wantLetter)
+			assert.Equal(t, err.Location.Value(), test.wantLocation)
+			assert.Equal(t, err.Result.Guess.String(), test.results[len(test.results)-1].Guess.String())
+			assert.Equal(t, err.ConflictingTurn.Value(), test.wantTurn)
+			assert.Equal(t, err.ConflictingResult.Guess.String(), test.wantGuess)
+		})
+	}
+}
+
+func TestWordRestrictionsUpdateInconsistentResult(t *testing.T) {
+	restrictions := InitWordRestrictions(3)
+
+	// There's nowhere left for the 'c'.
+	err := updateWithConflict(t, &restrictions, guessResult(t, "xyz", "---"), guessResult(t, "abc", "ggy"))
+
+	assert.Equal(t, err.Letter, 'c')
+	assert.Assert(t, !err.ConflictingTurn.HasValue())
+	assert.Assert(t, err.ConflictingResult == nil)
+	assert.Error(t, err, "The result for abc is inconsistent for the letter c at position 2. The letters b and c can't both be at index 1.")
+}
+
+func TestWordRestrictionsUpdateWrongLength(t *testing.T) {
+	restrictions := InitWordRestrictions(3)
+	result := guessResult(t, "abcd", "----")
+
+	err := restrictions.Update(&result)
+
+	assert.ErrorContains(t, err, "must have 3 letters")
+	var restrictionErr *RestrictionError
+	assert.Assert(t, !errors.As(err, &restrictionErr))
+}
+
+func TestWordRestrictionsManualConflictWithTurn(t *testing.T) {
+	restrictions := InitWordRestrictions(3)
+	assert.NilError(t, restrictions.SetLetterAt('s', 0))
+	assert.NilError(t, restrictions.SetNoRepeatedLetters())
+
+	err := updateWithConflict(t, &restrictions, guessResult(t, "ssx", "gy-"))
+
+	assert.Equal(t, err.Letter, 's')
+	assert.Equal(t, err.ConflictingTurn.Value(), 1)
+	assert.Assert(t, err.ConflictingResult == nil)
+	assert.ErrorContains(t, err, "The result for ssx conflicts with turn 2 for the letter s")
+
+	// Conflicting manual restrictions are reported the same way.
+	result := guessResult(t, "abc", "-g-")
+	restrictions = InitWordRestrictions(3)
+	assert.NilError(t, restrictions.Update(&result))
+	before := restrictions.Summary().String()
+	var restrictionErr *RestrictionError
+	assert.Assert(t, errors.As(restrictions.SetLetterAt('c', 1), &restrictionErr))
+	assert.Equal(t, restrictionErr.Letter, 'c')
+	assert.Equal(t, restrictionErr.Location.Value(), uint8(1))
+	assert.Assert(t, restrictionErr.Result == nil)
+	assert.Equal(t, restrictionErr.ConflictingTurn.Value(), 0)
+	assert.Equal(t, restrictionErr.ConflictingResult.Guess.String(), "abc")
+	assert.ErrorContains(t, restrictionErr, "The restriction conflicts with turn 1 (abc) for the letter c at position 2.")
+	assert.Equal(t, restrictions.Summary().String(), before)
+}
+
+func TestWordRestrictionsDecodedConflictHasNoTurn(t *testing.T) {
+	original := restrictionsForGuesses(t, "abc", "axx")
+	data, err := json.Marshal(&original)
+	assert.NilError(t, err)
+	var restrictions WordRestrictions
+	assert.NilError(t, json.Unmarshal(data, &restrictions))
+
+	restrictionErr := updateWithConflict(t, &restrictions, guessResult(t, "xxa", "g--"))
+
+	assert.Equal(t, restrictionErr.Letter, 'x')
+	assert.Assert(t, !restrictionErr.ConflictingTurn.HasValue())
+}
+
+func TestWordRestrictionsCopyIsIndependent(t *testing.T) {
+	restrictions := restrictionsForGuesses(t, "abc", "axx")
+	copied := restrictions.Copy()
+	result := guessResult(t, "xbx", "-g-")
+
+	assert.NilError(t, copied.Update(&result))
+
+	assert.Equal(t, restrictions.State('b', 1), LetterRestrictionUnknown)
+	assert.Equal(t, copied.State('b', 1), LetterRestrictionHere)
+}
+
+func TestPossibleWordsFilterConflictLeavesWordsUnchanged(t *testing.T) {
+	bank, err := WordBankFromSlice([]string{"abc", "abd", "bcd", "cde"})
+	assert.NilError(t, err)
+	words := bank.Words()
+	first := guessResult(t, "abc", "gg-")
+	assert.NilError(t, words.Filter(&first))
+	assert.Equal(t, words.Len(), 1)
+
+	conflicting := guessResult(t, "bcd", "g--")
+	assert.ErrorContains(t, words.Filter(&conflicting), "conflicts with turn 1 (abc)")
+
+	assert.Equal(t, words.Len(), 1)
+	assert.Equal(t, words.At(0).String(), "abd")
+	// Later results still apply.
+	next := guessResult(t, "abd", "ggg")
+	assert.NilError(t, words.Filter(&next))
+	assert.Equal(t, words.Len(), 1)
+}
+
+func TestPossibleWordsCopyIsIndependent(t *testing.T) {
+	bank, err := WordBankFromSlice([]string{"abc", "abd", "bcd", "cde"})
+	assert.NilError(t, err)
+	words := bank.Words()
+	copied := words.Copy()
+	result := guessResult(t, "abc", "gg-")
+
+	assert.NilError(t, copied.Filter(&result))
+
+	assert.Equal(t, copied.Len(), 1)
+	assert.Equal(t, words.Len(), 4)
+	// The original's restrictions are unchanged, so this doesn't conflict.
+	conflicting := guessResult(t, "bcd", "g--")
+	assert.NilError(t, words.Filter(&conflicting))
+}
+
+func TestMaxScoreGuesserUpdateConflictLeavesGuesserUnchanged(t *testing.T) {
+	bank, err := WordBankFromSlice([]string{"abc", "abd", "bcd", "cde"})
+	assert.NilError(t, err)
+	scorer, err := InitMaxEliminationsScorer(&bank)
+	assert.NilError(t, err)
+	guesser := InitMaxScoreGuesser(&bank, &scorer, GuessModeAll)
+	first := guessResult(t, "cde", "y--")
+	assert.NilError(t, guesser.Update(&first))
+	numPossible := guesser.PossibleWords().Len()
+	numUnguessed := guesser.unguessedWords.Len()
+
+	conflicting := guessResult(t, "cxx", "g--")
+	assert.ErrorContains(t, guesser.Update(&conflicting), "conflicts with turn 1 (cde)")
+
+	assert.Equal(t, guesser.PossibleWords().Len(), numPossible)
+	assert.Equal(t, guesser.unguessedWords.Len(), numUnguessed)
+}
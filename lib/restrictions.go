@@ -2,7 +2,9 @@ package go_wordle_solver
 
 import (
 	"fmt"
+	"math/bits"
 
+	"golang.org/x/exp/maps"
 	"golang.org/x/exp/slices"
 )
 
@@ -39,6 +41,10 @@ type presentLetter struct {
 	numNotHere uint8
 	// The status of the letter at each location in the word.
 	locatedState []locatedLetterState
+	// The locations marked [llsHere], as a mask over the letter slots of a packed word.
+	packedHere packedWord
+	// The locations marked [llsNotHere], as a mask over the letter slots of a packed word.
+	packedNotHere packedWord
 }
 
 // Constructs a [presentLetter] for use with words of the given length.
@@ -59,6 +65,21 @@ func (self *presentLetter) state(index uint8) locatedLetterState {
 	return self.locatedState[index]
 }
 
+// Sets the state at the given index, keeping the packed masks in sync.
+func (self *presentLetter) setState(index uint8, state locatedLetterState) {
+	self.locatedState[index] = state
+	if int(index) >= MaxPackedWordLength {
+		return
+	}
+	slotBit := packedWord(1) << (int(index)*packedBitsPerLetter + packedBitsPerLetter - 1)
+	switch state {
+	case llsHere:
+		self.packedHere |= slotBit
+	case llsNotHere:
+		self.packedNotHere |= slotBit
+	}
+}
+
 // Sets that this letter must be at the given index.
 //
 // If the required count for this letter is known, then this may fill any remaining [llsUnknown]
@@ -73,7 +94,7 @@ func (self *presentLetter) setMustBeAt(index uint8) error {
 	case llsNotHere:
 		return fmt.Errorf("Can't set letter to %s at index %v since it's already marked as %s.", llsHere, index, previous)
 	}
-	self.locatedState[index] = llsHere
+	self.setState(index, llsHere)
 	self.numHere += 1
 	if self.numHere > self.minCount {
 		self.minCount = self.numHere
@@ -110,7 +131,7 @@ func (self *presentLetter) setMustNotBeAt(index uint8) error {
 	case llsHere:
 		return fmt.Errorf("Can't set letter to %s at index %v since it's already marked as %s.", llsNotHere, index, previous)
 	}
-	self.locatedState[index] = llsNotHere
+	self.setState(index, llsNotHere)
 	self.numNotHere += 1
 	maxPossibleHere := uint8(len(self.locatedState)) - self.numNotHere
 	if maxPossibleHere == self.minCount {
@@ -186,7 +207,7 @@ func (self *presentLetter) setUnknownsTo(newState locatedLetterState) {
 	}
 	for i, state := range self.locatedState {
 		if state == llsUnknown {
-			self.locatedState[i] = newState
+			self.setState(uint8(i), newState)
 			*countToUpdate += 1
 		}
 	}
@@ -235,11 +256,21 @@ func (lr LetterRestriction) String() string {
 // WordRestrictions defines letter restrictions that a word must adhere to, such as "the first
 // letter of the word must be 'a'".
 //
-// Restrictions are derived from [GuessResult]s.
+// Restrictions are derived from [GuessResult]s, or can be added directly, e.g. with
+// [WordRestrictions.SetLetterAt].
 type WordRestrictions struct {
 	wordLength        uint8
 	presentLetters    map[rune]*presentLetter
 	notPresentLetters []rune
+	// The locations that letters are known not to be at, for letters that aren't known to be
+	// present. Never includes every location.
+	excludedLocations map[rune][]uint8
+	// The maximum number of times letters can appear, if less than the word length.
+	maxCounts map[rune]uint8
+	// Whether each letter can appear at most once.
+	noRepeatedLetters bool
+	// The updates applied so far, used to explain conflicts. See [RestrictionError].
+	updates []restrictionUpdate
 }
 
 // Creates a [WordRestrictions] object for the given word length with all letters unknown.
@@ -248,9 +279,63 @@ func InitWordRestrictions(wordLength uint8) WordRestrictions {
 		wordLength,
 		make(map[rune]*presentLetter, wordLength),
 		make([]rune, 0, 13),
+		nil,
+		nil,
+		false,
+		nil,
 	}
 }
 
+// Copy returns an independent copy of these restrictions.
+func (self *WordRestrictions) Copy() WordRestrictions {
+	presentLetters := make(map[rune]*presentLetter, len(self.presentLetters))
+	for letter, presence := range self.presentLetters {
+		presenceCopy := *presence
+		presenceCopy.locatedState = slices.Clone(presence.locatedState)
+		presentLetters[letter] = &presenceCopy
+	}
+	var excludedLocations map[rune][]uint8
+	if self.excludedLocations != nil {
+		excludedLocations = make(map[rune][]uint8, len(self.excludedLocations))
+		for letter, locations := range self.excludedLocations {
+			excludedLocations[letter] = slices.Clone(locations)
+		}
+	}
+	var maxCounts map[rune]uint8
+	if self.maxCounts != nil {
+		maxCounts = maps.Clone(self.maxCounts)
+	}
+	return WordRestrictions{
+		self.wordLength,
+		presentLetters,
+		slices.Clone(self.notPresentLetters),
+		excludedLocations,
+		maxCounts,
+		self.noRepeatedLetters,
+		slices.Clip(self.updates),
+	}
+}
+
+// restrictionUpdate is a single change to a [WordRestrictions].
+type restrictionUpdate struct {
+	// The result that this update adds, or nil if the update adds restrictions directly.
+	result *GuessResult
+	// Applies this update to the given restrictions.
+	apply func(restrictions *WordRestrictions) error
+}
+
+// apply applies the update to a copy of these restrictions, and only keeps the copy if the update
+// succeeds. This way, a failed update leaves these restrictions unchanged.
+func (self *WordRestrictions) apply(update restrictionUpdate) error {
+	updated := self.Copy()
+	if err := update.apply(&updated); err != nil {
+		return self.explain(update, err)
+	}
+	updated.updates = append(updated.updates, update)
+	*self = updated
+	return nil
+}
+
 // WordRestrictionsFromResult returns the restrictions imposed by the given result.
 func WordRestrictionsFromResult(result *GuessResult) (WordRestrictions, error) {
 	restrictions := InitWordRestrictions(uint8(result.Guess.Len()))
@@ -260,8 +345,22 @@ func WordRestrictionsFromResult(result *GuessResult) (WordRestrictions, error) {
 
 // Update adds restrictions arising from the given result.
 //
-// Returns an error if the result is incompatible with the existing restrictions.
+// Returns an error if the result is incompatible with the existing restrictions, in which case
+// these restrictions are unchanged. If the result conflicts, the error is a [*RestrictionError].
 func (self *WordRestrictions) Update(guessResult *GuessResult) error {
+	if guessResult.Guess.Len() != int(self.wordLength) || len(guessResult.Results) != int(self.wordLength) {
+		return fmt.Errorf("The guess (%s) and its results must have %v letters.", guessResult.Guess, self.wordLength)
+	}
+	result := GuessResult{guessResult.Guess, slices.Clone(guessResult.Results)}
+	return self.apply(restrictionUpdate{
+		result: &result,
+		apply: func(restrictions *WordRestrictions) error {
+			return restrictions.update(&result)
+		},
+	})
+}
+
+func (self *WordRestrictions) update(guessResult *GuessResult) error {
 	var err error
 	guessLen := guessResult.Guess.Len()
 	for i := 0; i < guessLen; i++ {
@@ -275,36 +374,67 @@ func (self *WordRestrictions) Update(guessResult *GuessResult) error {
 			err = self.setLetterNotPresent(letter, uint8(i), guessResult)
 		}
 		if err != nil {
-			return err
+			return newRestrictionError(letter, OptionalOf(uint8(i)), err)
 		}
 	}
-	return nil
+	return self.inferFromLimits()
 }
 
 // IsSatisfiedBy returns true iff the given word satisfies these restrictions.
+//
+// This uses a faster implementation when the word is packed (see [Word]).
 func (self *WordRestrictions) IsSatisfiedBy(word Word) bool {
-	wordLen := word.Len()
-	return wordLen == int(self.wordLength) &&
-		allPairs(self.presentLetters, func(letter rune, presence *presentLetter) bool {
-			countFound := uint8(0)
-			for i := 0; i < wordLen; i++ {
-				wordLetter := word.At(i)
-				if wordLetter == letter {
-					countFound += 1
-					if presence.state(uint8(i)) == llsNotHere {
-						return false
-					}
-				} else if presence.state(uint8(i)) == llsHere {
+	if word.Len() != int(self.wordLength) {
+		return false
+	}
+	if word.isPacked() {
+		return self.isSatisfiedByPacked(word.packed) && self.satisfiesLimits(word)
+	}
+	return isSatisfiedBy(self, word.runes) && self.satisfiesLimits(word)
+}
+
+func (self *WordRestrictions) isSatisfiedByPacked(word packedWord) bool {
+	lengthMask := word.lengthMask()
+	return allPairs(self.presentLetters, func(letter rune, presence *presentLetter) bool {
+		matches := packedWord(0)
+		if letter <= maxPackedLetter {
+			matches = word.matchingLetters(broadcastPacked(byte(letter))) & lengthMask
+		}
+		if matches&presence.packedNotHere != 0 || presence.packedHere&^matches != 0 {
+			return false
+		}
+		countFound := uint8(bits.OnesCount64(uint64(matches)))
+		if presence.maybeRequiredCount.HasValue() {
+			return countFound == presence.maybeRequiredCount.Value()
+		}
+		return countFound >= presence.minCount
+	}) &&
+		allValues(self.notPresentLetters, func(letter rune) bool {
+			return letter > maxPackedLetter ||
+				word.matchingLetters(broadcastPacked(byte(letter)))&lengthMask == 0
+		})
+}
+
+func isSatisfiedBy[L letter](self *WordRestrictions, word []L) bool {
+	return allPairs(self.presentLetters, func(letter rune, presence *presentLetter) bool {
+		countFound := uint8(0)
+		for i, wordLetter := range word {
+			if rune(wordLetter) == letter {
+				countFound += 1
+				if presence.state(uint8(i)) == llsNotHere {
 					return false
 				}
+			} else if presence.state(uint8(i)) == llsHere {
+				return false
 			}
-			if presence.maybeRequiredCount.HasValue() {
-				return countFound == presence.maybeRequiredCount.Value()
-			}
-			return countFound >= presence.minCount
-		}) &&
-		word.AllLetters(func(letter rune) bool {
-			return !slices.Contains(self.notPresentLetters, letter)
+		}
+		if presence.maybeRequiredCount.HasValue() {
+			return countFound == presence.maybeRequiredCount.Value()
+		}
+		return countFound >= presence.minCount
+	}) &&
+		allValues(word, func(letter L) bool {
+			return !slices.Contains(self.notPresentLetters, rune(letter))
 		})
 }
 
@@ -346,12 +476,11 @@ func (self *WordRestrictions) setLetterHere(
 	location uint8,
 	result *GuessResult,
 ) error {
-	presence, isPresent := self.presentLetters[letter]
-	if !isPresent {
-		presence = newPresentLetter(self.wordLength)
-		self.presentLetters[letter] = presence
+	presence, err := self.presentLetterFor(letter)
+	if err != nil {
+		return err
 	}
-	err := presence.setMustBeAt(location)
+	err = presence.setMustBeAt(location)
 	if err != nil {
 		return err
 	}
@@ -372,13 +501,14 @@ func (self *WordRestrictions) setLetterHere(
 		return err
 	}
 
-	// Remove this location possibility from other present letters.
-	for otherLetter, otherPresence := range self.presentLetters {
+	// Remove this location possibility from other present letters. These are visited in order, so
+	// that any conflict is reported consistently.
+	for _, otherLetter := range self.sortedPresentLetters() {
 		if otherLetter == letter {
 			continue
 		}
 
-		err = otherPresence.setMustNotBeAt(location)
+		err = self.presentLetters[otherLetter].setMustNotBeAt(location)
 		if err != nil {
 			return err
 		}
@@ -391,12 +521,11 @@ func (self *WordRestrictions) setLetterPresentNotHere(
 	location uint8,
 	result *GuessResult,
 ) error {
-	presence, isPresent := self.presentLetters[letter]
-	if !isPresent {
-		presence = newPresentLetter(self.wordLength)
-		self.presentLetters[letter] = presence
+	presence, err := self.presentLetterFor(letter)
+	if err != nil {
+		return err
 	}
-	err := presence.setMustNotBeAt(location)
+	err = presence.setMustNotBeAt(location)
 	if err != nil {
 		return err
 	}
@@ -414,18 +543,28 @@ func (self *WordRestrictions) setLetterNotPresent(
 		if presence.state(location) == llsHere {
 			return fmt.Errorf("Can't mark the letter %c as not present at %v since it's already marked as present here.", letter, location)
 		}
-		return presence.setRequiredCount(numTimesPresent)
+		return setPresentLetterNotPresentAt(presence, location, numTimesPresent)
 	} else if numTimesPresent > 0 {
-		pl := newPresentLetter(uint8(result.Guess.Len()))
-		self.presentLetters[letter] = pl
-		return pl.setRequiredCount(numTimesPresent)
-	}
-	if !slices.Contains(self.notPresentLetters, letter) {
-		self.notPresentLetters = append(self.notPresentLetters, letter)
+		pl, err := self.presentLetterFor(letter)
+		if err != nil {
+			return err
+		}
+		return setPresentLetterNotPresentAt(pl, location, numTimesPresent)
 	}
+	self.addNotPresentLetter(letter)
 	return nil
 }
 
+// setPresentLetterNotPresentAt applies a [LetterResultNotPresent] result for a letter that is in
+// the word. This means the letter isn't at this location, or it would have been marked correct, and
+// it only appears as many times as it was marked correct or present.
+func setPresentLetterNotPresentAt(presence *presentLetter, location uint8, numTimesPresent uint8) error {
+	if err := presence.setMustNotBeAt(location); err != nil {
+		return err
+	}
+	return presence.setRequiredCount(numTimesPresent)
+}
+
 func countNumTimesInGuess(letter rune, guessResult *GuessResult) uint8 {
 	var sum uint8 = 0
 	guessLen := guessResult.Guess.Len()
@@ -1,6 +1,7 @@
 package go_wordle_solver
 
 import (
+	"math/rand"
 	"testing"
 
 	"gotest.tools/v3/assert"
@@ -303,3 +304,106 @@ func TestWordRestrictionsIsSatisfiedByWithMinCount(t *testing.T) {
 	assert.Equal(t, restrictions.IsSatisfiedBy(WordFromString("edba")), false)
 	assert.Equal(t, restrictions.IsSatisfiedBy(WordFromString("ebbd")), false)
 }
+
+func TestWordRestrictionsIsSatisfiedByPackedMatchesRunes(t *testing.T) {
+	restrictions := InitWordRestrictions(4)
+
+	assert.NilError(t, restrictions.Update(&GuessResult{
+		Guess: WordFromString("abbc"),
+		Results: []LetterResult{
+			LetterResultPresentNotHere,
+			LetterResultNotPresent,
+			LetterResultCorrect,
+			LetterResultNotPresent,
+		},
+	}))
+
+	for _, word := range []string{"edba", "dabe", "daba", "bdba", "dcba", "adbd"} {
+		assert.Equal(t,
+			restrictions.IsSatisfiedBy(WordFromString(word)),
+			restrictions.IsSatisfiedBy(wordFromRunes([]rune(word))),
+			word)
+	}
+}
+
+func benchmarkIsSatisfiedBy(b *testing.B, toWord func(string) Word) {
+	restrictions := InitWordRestrictions(5)
+	err := restrictions.Update(&GuessResult{
+		Guess: WordFromString("sassy"),
+		Results: []LetterResult{
+			LetterResultPresentNotHere,
+			LetterResultPresentNotHere,
+			LetterResultCorrect,
+			LetterResultNotPresent,
+			LetterResultNotPresent,
+		},
+	})
+	if err != nil {
+		b.Fatal(err)
+	}
+	words := []Word{toWord("mesas"), toWord("other"), toWord("basis"), toWord("asset")}
+
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		restrictions.IsSatisfiedBy(words[n%len(words)])
+	}
+}
+
+func BenchmarkIsSatisfiedByPacked(b *testing.B) {
+	benchmarkIsSatisfiedBy(b, WordFromString)
+}
+
+func BenchmarkIsSatisfiedByRunes(b *testing.B) {
+	benchmarkIsSatisfiedBy(b, func(s string) Word { return wordFromRunes([]rune(s)) })
+}
+
+// restrictFromFeedback updates the restrictions with the feedback for each guess, asserting that
+// the objective still satisfies them.
+func restrictFromFeedback(t *testing.T, restrictions *WordRestrictions, objective Word, guesses ...Word) {
+	t.Helper()
+	for _, guess := range guesses {
+		result, err := GetResultForGuess(objective, guess)
+		assert.NilError(t, err)
+
+		assert.NilError(t, restrictions.Update(&result), "objective: %s, guess: %s", objective, guess)
+		assert.Assert(t, restrictions.IsSatisfiedBy(objective), "objective: %s, guess: %s", objective, guess)
+	}
+}
+
+func FuzzWordRestrictionsAreSatisfiedByObjective(f *testing.F) {
+	f.Add([]byte("mesas"), []byte("sassyabbeyeerie"))
+	f.Add([]byte("abba"), []byte("babbaaaa"))
+	f.Add([]byte("abc"), []byte("cbacab"))
+	f.Fuzz(func(t *testing.T, objectiveData, guessesData []byte) {
+		length := len(objectiveData)
+		if length == 0 || length > MaxPackedWordLength || len(guessesData) < length {
+			t.Skip()
+		}
+		objective := WordFromString(fuzzWord(objectiveData))
+		var guesses []Word
+		for i := 0; i+length <= len(guessesData) && len(guesses) < 6; i += length {
+			guesses = append(guesses, WordFromString(fuzzWord(guessesData[i:i+length])))
+		}
+		restrictions := InitWordRestrictions(uint8(length))
+
+		restrictFromFeedback(t, &restrictions, objective, guesses...)
+	})
+}
+
+func TestWordRestrictionsAreSatisfiedByObjectiveForWordList(t *testing.T) {
+	answers, err := BuiltinWordBank("wordle-answers")
+	assert.NilError(t, err)
+	objectives := answers.Words()
+	guessBank, err := BuiltinWordBank("wordle-words")
+	assert.NilError(t, err)
+	guesses := guessBank.Words()
+	rng := rand.New(rand.NewSource(1))
+
+	for i := 0; i < objectives.Len(); i++ {
+		restrictions := InitWordRestrictions(5)
+		numGuesses := rng.Intn(4) + 1
+		for n := 0; n < numGuesses; n++ {
+			restrictFromFeedback(t, &restrictions, objectives.At(i), guesses.At(rng.Intn(guesses.Len())))
+		}
+	}
+}
@@ -36,29 +36,187 @@ func (lr LetterResult) String() string {
 	}
 }
 
-// A compressed form of LetterResults. Can only store vectors of up to
-// [MaxLettersInCompressedGuessResult] results.
+// The characters used to represent each [LetterResult] in a compact string, indexed by result.
+const letterResultChars = "?gy-"
+
+// LetterResultsFromString parses a compact string of letter results, with one character per
+// letter, as produced by [LetterResultsString].
+//
+// The following characters are accepted (case-insensitive):
+//
+//   - 'g' -> [LetterResultCorrect] (i.e. green)
+//   - 'y' -> [LetterResultPresentNotHere] (i.e. yellow)
+//   - '-', '.', 'x', or 'b' -> [LetterResultNotPresent] (i.e. grey or black)
+//
+// For example, "gy--g" means the first and last letters were correct, and the second letter was
+// present but not in the right location.
+func LetterResultsFromString(s string) ([]LetterResult, error) {
+	results := make([]LetterResult, 0, len(s))
+	for i, c := range s {
+		switch c {
+		case 'g', 'G':
+			results = append(results, LetterResultCorrect)
+		case 'y', 'Y':
+			results = append(results, LetterResultPresentNotHere)
+		case '-', '.', 'x', 'X', 'b', 'B':
+			results = append(results, LetterResultNotPresent)
+		default:
+			return nil, fmt.Errorf("Invalid letter result '%c' at index %v. Expected one of 'g', 'y', or '-'.", c, i)
+		}
+	}
+	return results, nil
+}
+
+// LetterResultsString converts the given letter results to a compact string, with one character
+// per letter: 'g' for [LetterResultCorrect], 'y' for [LetterResultPresentNotHere], and '-' for
+// [LetterResultNotPresent]. [LetterResultUnknown] is represented by '?'.
+func LetterResultsString(results []LetterResult) string {
+	chars := make([]byte, len(results))
+	for i, result := range results {
+		if int(result) < len(letterResultChars) {
+			chars[i] = letterResultChars[result]
+		} else {
+			chars[i] = '?'
+		}
+	}
+	return string(chars)
+}
+
+// CompressedGuessResult is a dense, base-3 encoding of a list of [LetterResult]s.
+//
+// Each letter result is stored as a single base-3 digit, with the first letter in the least
+// significant digit: [LetterResultCorrect] is 0, [LetterResultPresentNotHere] is 1, and
+// [LetterResultNotPresent] is 2. This means that all results for words of length n are in the
+// range [0, 3^n), so they can be used directly as an index into an array of that size. For
+// example, there are 243 possible values for five letter words.
+//
+// This can only store vectors of up to [MaxLettersInCompressedGuessResult] results. See
+// [CompressedGuessResult64] for longer words.
 type CompressedGuessResult uint32
 
 // How many letters can be stored in [CompressedGuessResult].
-const MaxLettersInCompressedGuessResult uint8 = 32 / 3
+const MaxLettersInCompressedGuessResult uint8 = 20
+
+// CompressedGuessResult64 is the same as [CompressedGuessResult], but can store vectors of up to
+// [MaxLettersInCompressedGuessResult64] results.
+type CompressedGuessResult64 uint64
+
+// How many letters can be stored in [CompressedGuessResult64].
+const MaxLettersInCompressedGuessResult64 uint8 = 40
+
+// The number of distinct values a single letter can have in a compressed result.
+const numCompressedLetterResults = 3
+
+type compressedGuessResult interface {
+	~uint32 | ~uint64
+}
 
 // Creates a compressed form of the given letter results.
 //
-// Returns an error if letterResults has more than [MaxLettersInCompressedGuessResult] values.
+// Returns an error if letterResults has more than [MaxLettersInCompressedGuessResult] values, or
+// if any of the results are [LetterResultUnknown].
 func CompressResults(letterResults []LetterResult) (CompressedGuessResult, error) {
-	if len(letterResults) > int(MaxLettersInCompressedGuessResult) {
-		return 0, fmt.Errorf("Results can only be compressed with up to %v letters. This result has %v.", MaxLettersInCompressedGuessResult, len(letterResults))
+	return compressResults[CompressedGuessResult](letterResults, MaxLettersInCompressedGuessResult)
+}
+
+// Creates a compressed form of the given letter results.
+//
+// Returns an error if letterResults has more than [MaxLettersInCompressedGuessResult64] values,
+// or if any of the results are [LetterResultUnknown].
+func CompressResults64(letterResults []LetterResult) (CompressedGuessResult64, error) {
+	return compressResults[CompressedGuessResult64](letterResults, MaxLettersInCompressedGuessResult64)
+}
+
+func compressResults[C compressedGuessResult](letterResults []LetterResult, maxLetters uint8) (C, error) {
+	if len(letterResults) > int(maxLetters) {
+		return 0, fmt.Errorf("Results can only be compressed with up to %v letters. This result has %v.", maxLetters, len(letterResults))
 	}
-	var data CompressedGuessResult = 0
-	index := 0
-	for _, result := range letterResults {
-		data |= 1 << (index + int(result))
-		index += 3
+	var data C = 0
+	// Iterate in reverse so that the first letter ends up in the least significant digit.
+	for i := len(letterResults) - 1; i >= 0; i-- {
+		result := letterResults[i]
+		if result == LetterResultUnknown || result > LetterResultNotPresent {
+			return 0, fmt.Errorf("Can't compress result %s at index %v.", result, i)
+		}
+		data = data*numCompressedLetterResults + C(result-LetterResultCorrect)
 	}
 	return data, nil
 }
 
+// Decompress converts this back into a list of [LetterResult]s for a word of the given length.
+//
+// Returns an error if the word length is longer than [MaxLettersInCompressedGuessResult], or if
+// this value is too large for the given word length.
+func (c CompressedGuessResult) Decompress(wordLength uint8) ([]LetterResult, error) {
+	return decompressResults(c, wordLength, MaxLettersInCompressedGuessResult)
+}
+
+// Decompress converts this back into a list of [LetterResult]s for a word of the given length.
+//
+// Returns an error if the word length is longer than [MaxLettersInCompressedGuessResult64], or if
+// this value is too large for the given word length.
+func (c CompressedGuessResult64) Decompress(wordLength uint8) ([]LetterResult, error) {
+	return decompressResults(c, wordLength, MaxLettersInCompressedGuessResult64)
+}
+
+func decompressResults[C compressedGuessResult](data C, wordLength uint8, maxLetters uint8) ([]LetterResult, error) {
+	if wordLength > maxLetters {
+		return nil, fmt.Errorf("Results can only be decompressed with up to %v letters. Requested %v.", maxLetters, wordLength)
+	}
+	results := make([]LetterResult, wordLength)
+	for i := range results {
+		results[i] = LetterResultCorrect + LetterResult(data%numCompressedLetterResults)
+		data /= numCompressedLetterResults
+	}
+	if data != 0 {
+		return nil, fmt.Errorf("Compressed result is too large for words of length %v.", wordLength)
+	}
+	return results, nil
+}
+
+// NumCompressedGuessResults returns the number of distinct compressed results for words of the
+// given length, i.e. 3^wordLength.
+//
+// Returns an error if wordLength is greater than [MaxLettersInCompressedGuessResult64].
+func NumCompressedGuessResults(wordLength uint8) (uint64, error) {
+	if wordLength > MaxLettersInCompressedGuessResult64 {
+		return 0, fmt.Errorf("Results can only be compressed with up to %v letters. Requested %v.", MaxLettersInCompressedGuessResult64, wordLength)
+	}
+	num := uint64(1)
+	for i := uint8(0); i < wordLength; i++ {
+		num *= numCompressedLetterResults
+	}
+	return num, nil
+}
+
+// AllPossibleCompressedGuessResults returns all the compressed results that can be produced by
+// [GetResultForGuess] for words of the given length, in increasing order.
+//
+// This excludes results where exactly one letter is not [LetterResultCorrect] and that letter is
+// [LetterResultPresentNotHere], since there is nowhere else for that letter to go.
+//
+// Note that the number of results grows exponentially with the word length.
+//
+// Returns an error if wordLength is greater than [MaxLettersInCompressedGuessResult].
+func AllPossibleCompressedGuessResults(wordLength uint8) ([]CompressedGuessResult, error) {
+	if wordLength > MaxLettersInCompressedGuessResult {
+		return nil, fmt.Errorf("Results can only be compressed with up to %v letters. Requested %v.", MaxLettersInCompressedGuessResult, wordLength)
+	}
+	num, _ := NumCompressedGuessResults(wordLength)
+	all := make([]CompressedGuessResult, 0, num)
+	// A digit of 1 at a single location, i.e. a single letter that is present not here.
+	impossible := CompressedGuessResult(1)
+	for i := uint64(0); i < num; i++ {
+		c := CompressedGuessResult(i)
+		if c == impossible {
+			impossible *= numCompressedLetterResults
+			continue
+		}
+		all = append(all, c)
+	}
+	return all, nil
+}
+
 // GuessResult is the result of a single word guess.
 //
 // There is some complexity here when the guess has duplicate letters. Duplicate letters are
@@ -82,9 +240,9 @@ type GuessResult struct {
 // TurnData provides data about a single turn of a Wordle game.
 type TurnData struct {
 	// The guess that was made this turn.
-	Guess Word
+	Guess Word `json:"guess"`
 	// The number of possible words that remained at the start of this turn.
-	NumPossibleWordsBeforeGuess uint
+	NumPossibleWordsBeforeGuess uint `json:"num_possible_words_before_guess"`
 }
 
 // GameStatus indicates whether the game was won or lost.
@@ -112,18 +270,77 @@ func (gs GameStatus) String() string {
 // GameResult is the result of a Wordle game.
 type GameResult struct {
 	// Whether the game was won or lost.
-	Status GameStatus
+	Status GameStatus `json:"status"`
 	// Data for each turn that was played.
-	Turns []TurnData
+	Turns []TurnData `json:"turns"`
 }
 
 // GetResultForGuess determines the result of the given guess when applied to the given objective.
+//
+// Letters are compared exactly, so both words should already be normalized, e.g. with
+// [Normalizer.NormalizeWord]. [PossibleWords] and [PlayGameWithGuesser] normalize guesses and
+// objectives with the bank's [Normalizer] before comparing them.
+//
+// This uses a faster implementation when both words are packed (see [Word]).
 func GetResultForGuess(objective, guess Word) (GuessResult, error) {
-	// Convert to runes to properly handle unicode.
 	guessLen := guess.Len()
 	if objective.Len() != guessLen {
 		return GuessResult{}, fmt.Errorf("The guess (%s) must be the same length as the objective (length: %v).", guess, objective.Len())
 	}
+	results := make([]LetterResult, guessLen)
+	if objective.isPacked() && guess.isPacked() {
+		computePackedResults(objective.packed, guess.packed, results)
+	} else {
+		// Convert to runes to properly handle unicode.
+		computeResults(wordRunes(objective), wordRunes(guess), results)
+	}
+	return GuessResult{Guess: guess, Results: results}, nil
+}
+
+// wordRunes returns the letters of the given word as runes.
+func wordRunes(w Word) []rune {
+	if !w.isPacked() {
+		return w.runes
+	}
+	runes := make([]rune, w.Len())
+	for i := range runes {
+		runes[i] = w.At(i)
+	}
+	return runes
+}
+
+// computePackedResults populates results with the result of guessing guess for the given
+// objective.
+//
+// This produces the same results as [computeResults], but compares all the letters in a word at
+// once by using bitwise operations on the packed words.
+func computePackedResults(objective, guess packedWord, results []LetterResult) {
+	length := guess.len()
+	correct := objective.matchingLetters(guess) & guess.lengthMask()
+	// The objective letters that have not yet been matched to a guess letter.
+	unmatched := guess.lengthMask() &^ correct
+	for i := 0; i < length; i++ {
+		slotBit := packedWord(1) << (i*packedBitsPerLetter + packedBitsPerLetter - 1)
+		if correct&slotBit != 0 {
+			results[i] = LetterResultCorrect
+			continue
+		}
+		// Match this letter with the first unmatched instance in the objective, if any.
+		matches := objective.matchingLetters(broadcastPacked(guess.at(i))) & unmatched
+		if matches == 0 {
+			results[i] = LetterResultNotPresent
+			continue
+		}
+		results[i] = LetterResultPresentNotHere
+		unmatched &^= matches & -matches
+	}
+}
+
+// computeResults populates results with the result of guessing guess for the given objective.
+//
+// The objective, guess, and results must all be the same length.
+func computeResults[L letter](objective, guess []L, results []LetterResult) {
+	guessLen := len(guess)
 	// This algorithm does the following:
 	// * Assume none of the letters in the guess are present in the objective.
 	// * For each letter in the objective:
@@ -137,12 +354,11 @@ func GetResultForGuess(objective, guess Word) (GuessResult, error) {
 	//     * If the guess letter is equal, check if it's unset (i.e. "LetterResultNotPresent"). If
 	//       so, set it to LetterResultPresentNotHere. Here we're done, so we move on to the next
 	//       objective letter.
-	results := make([]LetterResult, guessLen)
 	fillSlice(results, LetterResultNotPresent)
 	for oi := 0; oi < guessLen; oi++ {
-		objectiveLetter := objective.At(oi)
+		objectiveLetter := objective[oi]
 		startI := 0
-		if objective.At(oi) == guess.At(oi) {
+		if objectiveLetter == guess[oi] {
 			existingResult := results[oi]
 			results[oi] = LetterResultCorrect
 			if existingResult != LetterResultPresentNotHere || oi == guessLen-1 {
@@ -151,7 +367,7 @@ func GetResultForGuess(objective, guess Word) (GuessResult, error) {
 			startI = oi + 1
 		}
 		for gi := startI; gi < guessLen; gi++ {
-			guessLetter := guess.At(gi)
+			guessLetter := guess[gi]
 			// Continue if this letter doesn't match.
 			if guessLetter != objectiveLetter {
 				continue
@@ -164,5 +380,4 @@ func GetResultForGuess(objective, guess Word) (GuessResult, error) {
 			break
 		}
 	}
-	return GuessResult{Guess: guess, Results: results}, nil
 }
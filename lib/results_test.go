@@ -2,6 +2,8 @@ package go_wordle_solver
 
 import (
 	"fmt"
+	"math/rand"
+	"strings"
 	"testing"
 
 	"gotest.tools/v3/assert"
@@ -146,6 +148,66 @@ func BenchmarkGetResultForGuessNoMatch(b *testing.B) {
 	}
 }
 
+func TestGetResultForGuessPackedMatchesRunes(t *testing.T) {
+	pairs := [][2]string{
+		{"mesas", "sassy"},
+		{"abba", "babb"},
+		{"abcb", "bcce"},
+		{"abcb", "defg"},
+		{"abcdefgh", "hgfedcba"},
+	}
+	for _, pair := range pairs {
+		packed, err := GetResultForGuess(WordFromString(pair[0]), WordFromString(pair[1]))
+		assert.NilError(t, err)
+		runes, err := GetResultForGuess(wordFromRunes([]rune(pair[0])), wordFromRunes([]rune(pair[1])))
+		assert.NilError(t, err)
+		mixed, err := GetResultForGuess(WordFromString(pair[0]), wordFromRunes([]rune(pair[1])))
+		assert.NilError(t, err)
+
+		assert.DeepEqual(t, packed.Results, runes.Results)
+		assert.DeepEqual(t, packed.Results, mixed.Results)
+	}
+}
+
+func TestGetResultForGuessPackedMatchesRunesForRandomWords(t *testing.T) {
+	rng := rand.New(rand.NewSource(1))
+	randomWord := func(length int) string {
+		letters := make([]byte, length)
+		for i := range letters {
+			letters[i] = "abc"[rng.Intn(3)]
+		}
+		return string(letters)
+	}
+	for n := 0; n < 1000; n++ {
+		length := rng.Intn(MaxPackedWordLength) + 1
+		objective := randomWord(length)
+		guess := randomWord(length)
+
+		packed, err := GetResultForGuess(WordFromString(objective), WordFromString(guess))
+		assert.NilError(t, err)
+		runes, err := GetResultForGuess(wordFromRunes([]rune(objective)), wordFromRunes([]rune(guess)))
+		assert.NilError(t, err)
+
+		assert.DeepEqual(t, packed.Results, runes.Results)
+	}
+}
+
+func BenchmarkGetResultForGuessPartialRunes(b *testing.B) {
+	objective := wordFromRunes([]rune("mesas"))
+	guess := wordFromRunes([]rune("sassy"))
+	for n := 0; n < b.N; n++ {
+		GetResultForGuess(objective, guess)
+	}
+}
+
+func BenchmarkGetResultForGuessNoMatchRunes(b *testing.B) {
+	objective := wordFromRunes([]rune("abcdefg"))
+	guess := wordFromRunes([]rune("hijklmn"))
+	for n := 0; n < b.N; n++ {
+		GetResultForGuess(objective, guess)
+	}
+}
+
 func TestCompressResultsEquality(t *testing.T) {
 	correct, err := CompressResults([]LetterResult{
 		LetterResultCorrect,
@@ -175,9 +237,293 @@ func TestCompressResultsEquality(t *testing.T) {
 }
 
 func TestCompressResultsLimits(t *testing.T) {
-	_, err := CompressResults(make([]LetterResult, MaxLettersInCompressedGuessResult))
+	results := make([]LetterResult, MaxLettersInCompressedGuessResult+1)
+	fillSlice(results, LetterResultNotPresent)
+
+	_, err := CompressResults(results[:MaxLettersInCompressedGuessResult])
+	assert.NilError(t, err)
+
+	_, err = CompressResults(results)
+	assert.Error(t, err, "Results can only be compressed with up to 20 letters. This result has 21.")
+}
+
+func TestCompressResults64Limits(t *testing.T) {
+	results := make([]LetterResult, MaxLettersInCompressedGuessResult64+1)
+	fillSlice(results, LetterResultNotPresent)
+
+	compressed, err := CompressResults64(results[:MaxLettersInCompressedGuessResult64])
+	assert.NilError(t, err)
+	num, err := NumCompressedGuessResults(MaxLettersInCompressedGuessResult64)
+	assert.NilError(t, err)
+	assert.Equal(t, uint64(compressed), num-1)
+
+	_, err = CompressResults64(results)
+	assert.Error(t, err, "Results can only be compressed with up to 40 letters. This result has 41.")
+}
+
+func TestCompressResultsIsDense(t *testing.T) {
+	correct, err := CompressResults([]LetterResult{
+		LetterResultCorrect,
+		LetterResultCorrect,
+		LetterResultCorrect,
+		LetterResultCorrect,
+		LetterResultCorrect,
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, correct, CompressedGuessResult(0))
+
+	notPresent, err := CompressResults([]LetterResult{
+		LetterResultNotPresent,
+		LetterResultNotPresent,
+		LetterResultNotPresent,
+		LetterResultNotPresent,
+		LetterResultNotPresent,
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, notPresent, CompressedGuessResult(242))
+
+	mixed, err := CompressResults([]LetterResult{
+		LetterResultPresentNotHere,
+		LetterResultNotPresent,
+		LetterResultCorrect,
+	})
+	assert.NilError(t, err)
+	assert.Equal(t, mixed, CompressedGuessResult(1+2*3))
+}
+
+func TestCompressResultsUnknown(t *testing.T) {
+	_, err := CompressResults([]LetterResult{LetterResultCorrect, LetterResultUnknown})
+	assert.Error(t, err, "Can't compress result unknown at index 1.")
+}
+
+func TestDecompressRoundTrip(t *testing.T) {
+	results := []LetterResult{
+		LetterResultPresentNotHere,
+		LetterResultNotPresent,
+		LetterResultCorrect,
+		LetterResultNotPresent,
+	}
+
+	compressed, err := CompressResults(results)
+	assert.NilError(t, err)
+	got, err := compressed.Decompress(4)
 	assert.NilError(t, err)
+	assert.DeepEqual(t, got, results)
 
-	_, err = CompressResults(make([]LetterResult, MaxLettersInCompressedGuessResult+1))
-	assert.Error(t, err, "Results can only be compressed with up to 10 letters. This result has 11.")
+	compressed64, err := CompressResults64(results)
+	assert.NilError(t, err)
+	got, err = compressed64.Decompress(4)
+	assert.NilError(t, err)
+	assert.DeepEqual(t, got, results)
+}
+
+func TestDecompressErrors(t *testing.T) {
+	_, err := CompressedGuessResult(9).Decompress(2)
+	assert.Error(t, err, "Compressed result is too large for words of length 2.")
+
+	_, err = CompressedGuessResult(0).Decompress(21)
+	assert.Error(t, err, "Results can only be decompressed with up to 20 letters. Requested 21.")
+}
+
+func TestNumCompressedGuessResults(t *testing.T) {
+	num, err := NumCompressedGuessResults(5)
+	assert.NilError(t, err)
+	assert.Equal(t, num, uint64(243))
+
+	_, err = NumCompressedGuessResults(41)
+	assert.Error(t, err, "Results can only be compressed with up to 40 letters. Requested 41.")
+}
+
+func TestAllPossibleCompressedGuessResults(t *testing.T) {
+	all, err := AllPossibleCompressedGuessResults(5)
+	assert.NilError(t, err)
+	// All but the five results with four correct letters and one present-not-here letter.
+	assert.Equal(t, len(all), 243-5)
+
+	all, err = AllPossibleCompressedGuessResults(2)
+	assert.NilError(t, err)
+	var decompressed [][]LetterResult
+	for _, compressed := range all {
+		results, err := compressed.Decompress(2)
+		assert.NilError(t, err)
+		decompressed = append(decompressed, results)
+	}
+	assert.DeepEqual(t, decompressed, [][]LetterResult{
+		{LetterResultCorrect, LetterResultCorrect},
+		{LetterResultNotPresent, LetterResultCorrect},
+		{LetterResultPresentNotHere, LetterResultPresentNotHere},
+		{LetterResultNotPresent, LetterResultPresentNotHere},
+		{LetterResultCorrect, LetterResultNotPresent},
+		{LetterResultPresentNotHere, LetterResultNotPresent},
+		{LetterResultNotPresent, LetterResultNotPresent},
+	})
+}
+
+func TestLetterResultsFromString(t *testing.T) {
+	results, err := LetterResultsFromString("gY-.xB")
+
+	assert.NilError(t, err)
+	assert.DeepEqual(t, results, []LetterResult{
+		LetterResultCorrect,
+		LetterResultPresentNotHere,
+		LetterResultNotPresent,
+		LetterResultNotPresent,
+		LetterResultNotPresent,
+		LetterResultNotPresent,
+	})
+}
+
+func TestLetterResultsFromStringInvalid(t *testing.T) {
+	_, err := LetterResultsFromString("gyz")
+
+	assert.Error(t, err, "Invalid letter result 'z' at index 2. Expected one of 'g', 'y', or '-'.")
+}
+
+func TestLetterResultsString(t *testing.T) {
+	s := LetterResultsString([]LetterResult{
+		LetterResultCorrect,
+		LetterResultPresentNotHere,
+		LetterResultNotPresent,
+		LetterResultUnknown,
+	})
+
+	assert.Equal(t, s, "gy-?")
+}
+
+// fuzzWord converts arbitrary bytes to a word of the same length, using only a few letters so that
+// repeated letters are common.
+func fuzzWord(data []byte) string {
+	letters := make([]byte, len(data))
+	for i, b := range data {
+		letters[i] = "abcde"[int(b)%5]
+	}
+	return string(letters)
+}
+
+// checkResultsAreValid asserts that results are the Wordle feedback for guess against objective.
+func checkResultsAreValid(t *testing.T, objective, guess string, results []LetterResult) {
+	t.Helper()
+	assert.Equal(t, len(results), len(guess))
+	for i := range guess {
+		assert.Equal(t, results[i] == LetterResultCorrect, objective[i] == guess[i], "objective: %s, guess: %s, index: %v", objective, guess, i)
+	}
+	for _, letter := range []byte(guess) {
+		// Each letter is marked present as many times as it can be matched with the objective, from
+		// left to right.
+		numMatched := 0
+		numInObjective := strings.Count(objective, string(letter))
+		for i := range guess {
+			if guess[i] != letter || results[i] == LetterResultNotPresent {
+				continue
+			}
+			numMatched++
+		}
+		assert.Equal(t, numMatched, minInt(strings.Count(guess, string(letter)), numInObjective), "objective: %s, guess: %s, letter: %c", objective, guess, letter)
+		for i := range guess {
+			if guess[i] == letter && results[i] == LetterResultNotPresent {
+				// No later instance can be marked present.
+				for j := i + 1; j < len(guess); j++ {
+					assert.Assert(t, guess[j] != letter || results[j] != LetterResultPresentNotHere, "objective: %s, guess: %s, index: %v", objective, guess, j)
+				}
+			}
+		}
+	}
+}
+
+func FuzzGetResultForGuess(f *testing.F) {
+	f.Add([]byte("mesas"), []byte("sassy"))
+	f.Add([]byte("abba"), []byte("babb"))
+	f.Add([]byte("abcdefgh"), []byte("hgfedcba"))
+	f.Fuzz(func(t *testing.T, objectiveData, guessData []byte) {
+		length := minInt(minInt(len(objectiveData), len(guessData)), MaxPackedWordLength)
+		if length == 0 {
+			t.Skip()
+		}
+		objective := fuzzWord(objectiveData[:length])
+		guess := fuzzWord(guessData[:length])
+
+		packed, err := GetResultForGuess(WordFromString(objective), WordFromString(guess))
+		assert.NilError(t, err)
+		runes, err := GetResultForGuess(wordFromRunes([]rune(objective)), wordFromRunes([]rune(guess)))
+		assert.NilError(t, err)
+
+		assert.DeepEqual(t, packed.Results, runes.Results)
+		checkResultsAreValid(t, objective, guess, packed.Results)
+	})
+}
+
+func TestGetResultForGuessIsValidForWordList(t *testing.T) {
+	bank, err := BuiltinWordBank("1000-wordle-shuffled")
+	assert.NilError(t, err)
+	words := bank.Words()
+	rng := rand.New(rand.NewSource(1))
+	for n := 0; n < 10000; n++ {
+		objective := words.At(rng.Intn(words.Len()))
+		guess := words.At(rng.Intn(words.Len()))
+
+		result, err := GetResultForGuess(objective, guess)
+		assert.NilError(t, err)
+
+		checkResultsAreValid(t, objective.String(), guess.String(), result.Results)
+	}
+}
+
+func FuzzCompressResults(f *testing.F) {
+	f.Add([]byte{0, 1, 2}, []byte{0, 1, 2})
+	f.Add([]byte{0, 1, 2}, []byte{2, 1, 0})
+	f.Add([]byte{0}, []byte{0, 0})
+	toResults := func(data []byte) []LetterResult {
+		results := make([]LetterResult, len(data))
+		for i, b := range data {
+			results[i] = LetterResultCorrect + LetterResult(b%numCompressedLetterResults)
+		}
+		return results
+	}
+	f.Fuzz(func(t *testing.T, aData, bData []byte) {
+		if len(aData) > int(MaxLettersInCompressedGuessResult) || len(bData) > int(MaxLettersInCompressedGuessResult) {
+			t.Skip()
+		}
+		a := toResults(aData)
+		b := toResults(bData)
+
+		compressedA, err := CompressResults(a)
+		assert.NilError(t, err)
+		compressedB, err := CompressResults(b)
+		assert.NilError(t, err)
+		compressedA64, err := CompressResults64(a)
+		assert.NilError(t, err)
+		compressedB64, err := CompressResults64(b)
+		assert.NilError(t, err)
+
+		// Compression is only injective for results of the same length.
+		if len(a) == len(b) {
+			isEqual := LetterResultsString(a) == LetterResultsString(b)
+			assert.Equal(t, compressedA == compressedB, isEqual)
+			assert.Equal(t, compressedA64 == compressedB64, isEqual)
+		}
+		decompressed, err := compressedA.Decompress(uint8(len(a)))
+		assert.NilError(t, err)
+		assert.DeepEqual(t, decompressed, a)
+	})
+}
+
+func TestCompressResultsIsInjectiveForWordList(t *testing.T) {
+	bank, err := BuiltinWordBank("wordle-answers")
+	assert.NilError(t, err)
+	words := bank.Words()
+	for _, guess := range []string{"sassy", "mesas", "eerie", "crane", "llama"} {
+		resultsByCompressed := make(map[CompressedGuessResult]string)
+		for i := 0; i < words.Len(); i++ {
+			result, err := GetResultForGuess(words.At(i), WordFromString(guess))
+			assert.NilError(t, err)
+			compressed, err := CompressResults(result.Results)
+			assert.NilError(t, err)
+
+			resultString := LetterResultsString(result.Results)
+			if previous, isPresent := resultsByCompressed[compressed]; isPresent {
+				assert.Equal(t, resultString, previous, "guess: %s", guess)
+			}
+			resultsByCompressed[compressed] = resultString
+		}
+	}
 }
@@ -3,6 +3,9 @@ package go_wordle_solver
 import (
 	"fmt"
 	"runtime"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
 )
 
 var maxThreads = runtime.NumCPU()
@@ -15,11 +18,54 @@ type WordScorer interface {
 	Reset(pw *PossibleWords)
 	// Updates the scorer with the latest guess, the updated set of restrictions, and the updated
 	// list of possible words.
+	//
+	// If this returns an error, the scorer must be unchanged.
 	Update(latestGuess Word, pw *PossibleWords) error
 	// Determines a score for the given word. The higher the score, the better the guess.
 	ScoreWord(word Word) int64
 }
 
+// A ConcurrentWordScorer is a [WordScorer] that declares whether [WordScorer.ScoreWord] can be
+// called from several goroutines at once, as long as no other method is called at the same time.
+//
+// [MaxScoreGuesser] only scores guesses in parallel if its scorer reports that this is safe.
+type ConcurrentWordScorer interface {
+	WordScorer
+
+	// IsSafeForConcurrentUse returns true iff ScoreWord can be called concurrently.
+	IsSafeForConcurrentUse() bool
+}
+
+// A CandidateAwareWordScorer is a [WordScorer] whose scores depend on which other words are
+// candidate guesses, e.g. because it normalizes scores across the candidates.
+//
+// [MaxScoreGuesser] sets the candidates before it scores them to choose each guess. Other words may
+// still be scored.
+type CandidateAwareWordScorer interface {
+	WordScorer
+
+	// SetCandidates sets the words that are about to be scored. The words may be modified after
+	// this returns, so the scorer must copy them if needed.
+	//
+	// The scorer may prepare to score the candidates using up to numThreads goroutines. This is
+	// only more than one if the scorer is a [ConcurrentWordScorer] that is safe for concurrent use.
+	SetCandidates(candidates *PossibleWords, numThreads int)
+}
+
+// setScorerCandidates sets the scorer's candidates if it's a [CandidateAwareWordScorer].
+func setScorerCandidates(scorer WordScorer, candidates *PossibleWords, numThreads int) {
+	if aware, isAware := scorer.(CandidateAwareWordScorer); isAware {
+		aware.SetCandidates(candidates, numThreads)
+	}
+}
+
+// isSafeForConcurrentUse returns true iff the scorer is a [ConcurrentWordScorer] that is safe for
+// concurrent use.
+func isSafeForConcurrentUse(scorer WordScorer) bool {
+	concurrent, isConcurrent := scorer.(ConcurrentWordScorer)
+	return isConcurrent && concurrent.IsSafeForConcurrentUse()
+}
+
 // This probabilistically calculates the expectation value for how many words will be eliminated by
 // each guess, and chooses the word that eliminates the most other guesses.
 //
@@ -144,6 +190,11 @@ func (self *MaxEliminationsScorer) Update(latestGuess Word, pw *PossibleWords) e
 	return nil
 }
 
+// IsSafeForConcurrentUse returns true, since scoring words only reads this scorer's state.
+func (self *MaxEliminationsScorer) IsSafeForConcurrentUse() bool {
+	return true
+}
+
 func (self *MaxEliminationsScorer) ScoreWord(w Word) int64 {
 	if self.isFirstRound {
 		if expectedEliminations, isPresent := self.firstExpectedEliminationsPerWord[w.String()]; isPresent {
@@ -179,29 +230,260 @@ func computeExpectedEliminationsChunk(startIndices <-chan int, errs chan<- error
 	done <- true
 }
 
+// The maximum number of distinct guess results for which computeExpectedEliminations counts
+// matches in a slice instead of a map.
+const maxResultsForSliceCounts uint64 = 729
+
 func computeExpectedEliminations(guess Word, possibleWords *PossibleWords) (float64, error) {
 	numPossible := possibleWords.Len()
+	numResults, err := NumCompressedGuessResults(uint8(guess.Len()))
+	if err == nil && numResults <= maxResultsForSliceCounts {
+		// Small words can count directly into a slice indexed by the compressed result.
+		matchingResults := make([]uint, numResults)
+		err = countMatchingResults(guess, possibleWords, func(compressed CompressedGuessResult) {
+			matchingResults[compressed]++
+		})
+		if err != nil {
+			return 0.0, err
+		}
+		return expectedEliminations(matchingResults, numPossible), nil
+	}
 	matchingResults := make(map[CompressedGuessResult]uint, numPossible)
+	err = countMatchingResults(guess, possibleWords, func(compressed CompressedGuessResult) {
+		matchingResults[compressed]++
+	})
+	if err != nil {
+		return 0.0, err
+	}
+	counts := make([]uint, 0, len(matchingResults))
+	for _, numMatched := range matchingResults {
+		counts = append(counts, numMatched)
+	}
+	return expectedEliminations(counts, numPossible), nil
+}
+
+// countMatchingResults calls countFn with the compressed result of the guess for each possible
+// objective word, using the possible words' [FeedbackRule].
+func countMatchingResults(guess Word, possibleWords *PossibleWords, countFn func(CompressedGuessResult)) error {
+	numPossible := possibleWords.Len()
+	rule := possibleWords.FeedbackRule()
 	for i := 0; i < numPossible; i++ {
 		objective := possibleWords.At(i)
-		result, err := GetResultForGuess(objective, guess)
+		result, err := rule.Feedback(objective, guess)
 		if err != nil {
-			return 0.0, err
+			return err
 		}
 		compressed, err := CompressResults(result.Results)
 		if err != nil {
-			return 0.0, err
-		}
-		var count uint = 1
-		if knownCount, isPresent := matchingResults[compressed]; isPresent {
-			count += knownCount
+			return err
 		}
-		matchingResults[compressed] = count
+		countFn(compressed)
 	}
+	return nil
+}
+
+// expectedEliminations computes the expected number of words eliminated, given the number of
+// possible words that match each distinct result.
+func expectedEliminations(numMatchedPerResult []uint, numPossible int) float64 {
 	numerator := uint(0)
-	for _, numMatched := range matchingResults {
+	for _, numMatched := range numMatchedPerResult {
 		numEliminated := uint(numPossible) - numMatched
 		numerator += numEliminated * numMatched
 	}
-	return float64(numerator) / float64(numPossible), nil
+	return float64(numerator) / float64(numPossible)
+}
+
+// letterCounts counts the letters in a set of possible words, and keeps the counts up to date as
+// the words are filtered.
+type letterCounts struct {
+	// The counted words, in the same order as the possible words.
+	words []Word
+	// The number of words with each letter at each location.
+	located []map[rune]int
+	// The number of words that contain each letter at least once.
+	containing map[rune]int
+}
+
+func countLetters(pw *PossibleWords) letterCounts {
+	counts := letterCounts{
+		words:      slices.Clone(pw.words),
+		located:    make([]map[rune]int, pw.restrictions.wordLength),
+		containing: make(map[rune]int),
+	}
+	for i := range counts.located {
+		counts.located[i] = make(map[rune]int)
+	}
+	for _, word := range counts.words {
+		counts.add(word, 1)
+	}
+	return counts
+}
+
+func (self *letterCounts) copy() letterCounts {
+	located := make([]map[rune]int, len(self.located))
+	for i, counts := range self.located {
+		located[i] = maps.Clone(counts)
+	}
+	return letterCounts{slices.Clone(self.words), located, maps.Clone(self.containing)}
+}
+
+// add adds delta to the counts for each letter in the word.
+func (self *letterCounts) add(word Word, delta int) {
+	for i := 0; i < word.Len(); i++ {
+		letter := word.At(i)
+		self.located[i][letter] += delta
+		if isFirstOccurrence(word, i) {
+			self.containing[letter] += delta
+		}
+	}
+}
+
+// update updates the counts to match the given possible words.
+//
+// If the possible words were filtered from the counted words, this only subtracts the words that
+// were removed. Otherwise, the words are counted again.
+func (self *letterCounts) update(pw *PossibleWords) {
+	var removed []Word
+	numKept := 0
+	for _, word := range self.words {
+		if numKept < pw.Len() && word.Equal(pw.At(numKept)) {
+			numKept++
+			continue
+		}
+		removed = append(removed, word)
+	}
+	if numKept != pw.Len() || len(removed) > numKept || len(self.located) != int(pw.restrictions.wordLength) {
+		*self = countLetters(pw)
+		return
+	}
+	for _, word := range removed {
+		self.add(word, -1)
+	}
+	self.words = slices.Clone(pw.words)
+}
+
+// isFirstOccurrence returns true iff the letter at the given index doesn't appear earlier in the
+// word.
+func isFirstOccurrence(word Word, index int) bool {
+	letter := word.At(index)
+	for i := 0; i < index; i++ {
+		if word.At(i) == letter {
+			return false
+		}
+	}
+	return true
+}
+
+// letterCountsScorer holds the state shared by the scorers that count the letters in the possible
+// words.
+type letterCountsScorer struct {
+	possibleWords *PossibleWords
+	// The counts for all the words in the bank. These are shared between copies.
+	initialCounts *letterCounts
+	counts        letterCounts
+}
+
+func initLetterCountsScorer(bank *WordBank) letterCountsScorer {
+	words := bank.Words()
+	counts := countLetters(&words)
+	return letterCountsScorer{&words, &counts, counts.copy()}
+}
+
+func (self *letterCountsScorer) copy() letterCountsScorer {
+	pwCopy := self.possibleWords.Copy()
+	return letterCountsScorer{&pwCopy, self.initialCounts, self.counts.copy()}
+}
+
+func (self *letterCountsScorer) Reset(pw *PossibleWords) {
+	self.possibleWords = pw
+	self.counts = self.initialCounts.copy()
+	self.counts.update(pw)
+}
+
+func (self *letterCountsScorer) Update(latestGuess Word, pw *PossibleWords) error {
+	self.possibleWords = pw
+	self.counts.update(pw)
+	return nil
+}
+
+// IsSafeForConcurrentUse returns true, since scoring words only reads the letter counts.
+func (self *letterCountsScorer) IsSafeForConcurrentUse() bool {
+	return true
+}
+
+// This scores words by how many possible words share each of their letters, in the same location
+// and anywhere in the word. Letters whose state is already known at their location don't score.
+//
+// Each letter scores the number of possible words with that letter in the same location, i.e. the
+// number of words for which it would be correct. Each distinct letter also scores the number of
+// possible words that contain it.
+//
+// This is much cheaper to compute than the [MaxEliminationsScorer], since it only keeps counts of
+// the letters in the possible words, and updates them as words are eliminated. When benchmarked
+// against the words in `data/1000-improved-words-shuffled.txt`, with the same list as the word
+// bank and the [MaxScoreGuesser]'s endgame search disabled, this averaged 3.38 guesses with
+// [GuessModeAll], and 3.30 guesses with [GuessModePossible]. The [MaxEliminationsScorer] averaged
+// 3.28 and 3.25 respectively.
+type LocatedLettersScorer struct {
+	letterCountsScorer
+}
+
+// Constructs a [LocatedLettersScorer] for the words in the given bank.
+func InitLocatedLettersScorer(bank *WordBank) LocatedLettersScorer {
+	return LocatedLettersScorer{initLetterCountsScorer(bank)}
+}
+
+func (self *LocatedLettersScorer) Copy() WordScorer {
+	return &LocatedLettersScorer{self.copy()}
+}
+
+func (self *LocatedLettersScorer) ScoreWord(w Word) int64 {
+	if w.Len() != len(self.counts.located) {
+		return 0
+	}
+	score := 0
+	for i := 0; i < w.Len(); i++ {
+		letter := w.At(i)
+		if self.possibleWords.restrictions.IsStateKnown(letter, uint8(i)) {
+			continue
+		}
+		score += self.counts.located[i][letter]
+		if isFirstOccurrence(w, i) {
+			score += self.counts.containing[letter]
+		}
+	}
+	return int64(score)
+}
+
+// This scores words by how many possible words contain each of their distinct letters, ignoring
+// where the letters are. Letters whose state is already known at their location don't score.
+//
+// This is the cheapest scorer to compute, but it's less effective than the
+// [LocatedLettersScorer]. When benchmarked in the same way, this averaged 3.52 guesses with
+// [GuessModeAll], and 3.35 guesses with [GuessModePossible].
+type LetterFrequencyScorer struct {
+	letterCountsScorer
+}
+
+// Constructs a [LetterFrequencyScorer] for the words in the given bank.
+func InitLetterFrequencyScorer(bank *WordBank) LetterFrequencyScorer {
+	return LetterFrequencyScorer{initLetterCountsScorer(bank)}
+}
+
+func (self *LetterFrequencyScorer) Copy() WordScorer {
+	return &LetterFrequencyScorer{self.copy()}
+}
+
+func (self *LetterFrequencyScorer) ScoreWord(w Word) int64 {
+	if w.Len() != len(self.counts.located) {
+		return 0
+	}
+	score := 0
+	for i := 0; i < w.Len(); i++ {
+		letter := w.At(i)
+		if isFirstOccurrence(w, i) && !self.possibleWords.restrictions.IsStateKnown(letter, uint8(i)) {
+			score += self.counts.containing[letter]
+		}
+	}
+	return int64(score)
 }
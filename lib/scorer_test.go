@@ -1,7 +1,6 @@
 package go_wordle_solver
 
 import (
-	"os"
 	"testing"
 
 	"gotest.tools/v3/assert"
@@ -64,11 +63,7 @@ func TestMaxEliminationsScoreWordWithUpdateAndReset(t *testing.T) {
 }
 
 func BenchmarkInitMaxEliminationsScorer(b *testing.B) {
-	f, err := os.Open("../data/1000-improved-words-shuffled.txt")
-	if err != nil {
-		b.Fatal(err)
-	}
-	bank, err := WordBankFromReader(f)
+	bank, err := BuiltinWordBank("1000-improved-shuffled")
 	if err != nil {
 		b.Fatal(err)
 	}
@@ -80,3 +75,146 @@ func BenchmarkInitMaxEliminationsScorer(b *testing.B) {
 		}
 	}
 }
+
+func TestLocatedLettersScoreWord(t *testing.T) {
+	bank, err := WordBankFromSlice([]string{"abc", "abd", "bad", "zza"})
+	assert.NilError(t, err)
+
+	scorer := InitLocatedLettersScorer(&bank)
+
+	// a: 2 here + 4 containing, b: 2 here + 3 containing, c: 1 here + 1 containing.
+	assert.Equal(t, scorer.ScoreWord(WordFromString("abc")), int64(13))
+	// z: 1 here + 1 containing, then 1 more here, a: 1 here + 4 containing.
+	assert.Equal(t, scorer.ScoreWord(WordFromString("zza")), int64(8))
+	assert.Equal(t, scorer.ScoreWord(WordFromString("xyz")), int64(1))
+	assert.Equal(t, scorer.ScoreWord(WordFromString("abcd")), int64(0))
+}
+
+func TestLetterFrequencyScoreWord(t *testing.T) {
+	bank, err := WordBankFromSlice([]string{"abc", "abd", "bad", "zza"})
+	assert.NilError(t, err)
+
+	scorer := InitLetterFrequencyScorer(&bank)
+
+	assert.Equal(t, scorer.ScoreWord(WordFromString("abc")), int64(8))
+	assert.Equal(t, scorer.ScoreWord(WordFromString("cab")), int64(8))
+	// Repeated letters only count once.
+	assert.Equal(t, scorer.ScoreWord(WordFromString("zza")), int64(5))
+	assert.Equal(t, scorer.ScoreWord(WordFromString("xyz")), int64(1))
+}
+
+func TestLetterScorersIgnoreKnownLetters(t *testing.T) {
+	bank, err := WordBankFromSlice([]string{"abc", "abd", "bad", "zza"})
+	assert.NilError(t, err)
+	located := InitLocatedLettersScorer(&bank)
+	frequency := InitLetterFrequencyScorer(&bank)
+	pw := bank.Words()
+	result := guessResult(t, "abx", "gg-")
+	assert.NilError(t, pw.Filter(&result))
+
+	assert.NilError(t, located.Update(result.Guess, &pw))
+	assert.NilError(t, frequency.Update(result.Guess, &pw))
+
+	// Still possible: abc, abd. Only the last letter is unknown.
+	assert.Equal(t, located.ScoreWord(WordFromString("abc")), int64(2))
+	assert.Equal(t, frequency.ScoreWord(WordFromString("abc")), int64(1))
+	assert.Equal(t, located.ScoreWord(WordFromString("cdx")), int64(2))
+	assert.Equal(t, frequency.ScoreWord(WordFromString("cdx")), int64(2))
+}
+
+func TestLetterCountsUpdateMatchesRecount(t *testing.T) {
+	bank, err := BuiltinWordBank("1000-improved-shuffled")
+	assert.NilError(t, err)
+	pw := bank.Words()
+	counts := countLetters(&pw)
+
+	for _, test := range []struct{ guess, result string }{
+		{"tares", "--y--"},
+		{"inlay", "-y-g-"},
+	} {
+		result := guessResult(t, test.guess, test.result)
+		assert.NilError(t, pw.Filter(&result))
+
+		counts.update(&pw)
+
+		assertSameLetterCounts(t, counts, countLetters(&pw))
+	}
+
+	// Counts for unrelated words are recomputed.
+	other := bank.Words()
+	other.Remove(other.At(0))
+	counts.update(&other)
+	assertSameLetterCounts(t, counts, countLetters(&other))
+}
+
+// assertSameLetterCounts checks that the counts are equal, ignoring letters with a count of zero.
+func assertSameLetterCounts(t *testing.T, got, want letterCounts) {
+	assert.DeepEqual(t, wordStrings(got.words), wordStrings(want.words))
+	assert.DeepEqual(t, nonZeroCounts(got.containing), nonZeroCounts(want.containing))
+	assert.Equal(t, len(got.located), len(want.located))
+	for i := range got.located {
+		assert.DeepEqual(t, nonZeroCounts(got.located[i]), nonZeroCounts(want.located[i]))
+	}
+}
+
+func wordStrings(words []Word) []string {
+	strs := make([]string, len(words))
+	for i, w := range words {
+		strs[i] = w.String()
+	}
+	return strs
+}
+
+func nonZeroCounts(counts map[rune]int) map[rune]int {
+	nonZero := make(map[rune]int)
+	for letter, count := range counts {
+		if count != 0 {
+			nonZero[letter] = count
+		}
+	}
+	return nonZero
+}
+
+func TestLetterScorersCopyAndReset(t *testing.T) {
+	bank, err := BuiltinWordBank("1000-improved-shuffled")
+	assert.NilError(t, err)
+	located := InitLocatedLettersScorer(&bank)
+	frequency := InitLetterFrequencyScorer(&bank)
+
+	for _, scorer := range []WordScorer{&located, &frequency} {
+		pw := bank.Words()
+		word := WordFromString("tares")
+		initialScore := scorer.ScoreWord(word)
+		copied := scorer.Copy()
+		result := guessResult(t, "inlay", "--y--")
+		assert.NilError(t, pw.Filter(&result))
+
+		assert.NilError(t, scorer.Update(result.Guess, &pw))
+		assert.Assert(t, scorer.ScoreWord(word) < initialScore, "%T", scorer)
+		assert.Equal(t, copied.ScoreWord(word), initialScore, "%T", scorer)
+
+		pw = bank.Words()
+		scorer.Reset(&pw)
+		assert.Equal(t, scorer.ScoreWord(word), initialScore, "%T", scorer)
+	}
+}
+
+func TestPlayGameWithLetterScorers(t *testing.T) {
+	bank, err := BuiltinWordBank("1000-improved-shuffled")
+	assert.NilError(t, err)
+	words := bank.Words()
+	located := InitLocatedLettersScorer(&bank)
+	frequency := InitLetterFrequencyScorer(&bank)
+
+	for _, scorer := range []WordScorer{&located, &frequency} {
+		for _, mode := range []GuessMode{GuessModeAll, GuessModePossible} {
+			guesser := InitMaxScoreGuesser(&bank, scorer.Copy(), mode)
+			for i := 0; i < words.Len(); i += 50 {
+				result, err := PlayGameWithGuesser(words.At(i), 20, &guesser)
+
+				assert.NilError(t, err)
+				assert.Equal(t, result.Status, GameSuccess, "%T %s: %s", scorer, mode, words.At(i))
+			}
+		}
+	}
+}
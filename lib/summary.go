@@ -0,0 +1,220 @@
+package go_wordle_solver
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+
+	"golang.org/x/exp/maps"
+	"golang.org/x/exp/slices"
+)
+
+// LetterSummary describes what is known about a single letter that is in the word.
+type LetterSummary struct {
+	Letter rune
+	// The locations where this letter is known to be, in increasing order.
+	KnownLocations []uint8
+	// The locations where this letter is known not to be, in increasing order.
+	ExcludedLocations []uint8
+	// The minimum number of times this letter appears in the word.
+	MinCount uint8
+	// The exact number of times this letter appears in the word, if known.
+	ExactCount Optional[uint8]
+	// The maximum number of times this letter appears in the word, if it is less than the word
+	// length and the exact count is not known. Repeated letters being forbidden is not included.
+	MaxCount Optional[uint8]
+}
+
+// RestrictionsSummary is a read-only view of what is known about a word, as described by
+// [WordRestrictions].
+//
+// Use [RestrictionsSummary.String] to get a human-readable description.
+type RestrictionsSummary struct {
+	WordLength uint8
+	// The letters known to be in the word, in sorted order.
+	PresentLetters []LetterSummary
+	// The letters known not to be in the word, in sorted order.
+	NotPresentLetters []rune
+	// The letters that are not known to be in the word, but that have excluded locations or a
+	// maximum count, in sorted order. These have a MinCount of 0.
+	OtherLetters []LetterSummary
+	// Whether each letter appears at most once.
+	NoRepeatedLetters bool
+}
+
+// Summary returns a read-only view of these restrictions.
+//
+// The returned summary does not change if these restrictions are updated.
+func (self *WordRestrictions) Summary() RestrictionsSummary {
+	letters := maps.Keys(self.presentLetters)
+	slices.Sort(letters)
+	presentLetters := make([]LetterSummary, len(letters))
+	for i, letter := range letters {
+		presence := self.presentLetters[letter]
+		summary := LetterSummary{
+			Letter:     letter,
+			MinCount:   presence.minCount,
+			ExactCount: presence.maybeRequiredCount,
+		}
+		if maxCount, hasMax := self.maxCounts[letter]; hasMax && !presence.maybeRequiredCount.HasValue() {
+			summary.MaxCount = OptionalOf(maxCount)
+		}
+		for location, state := range presence.locatedState {
+			switch state {
+			case llsHere:
+				summary.KnownLocations = append(summary.KnownLocations, uint8(location))
+			case llsNotHere:
+				summary.ExcludedLocations = append(summary.ExcludedLocations, uint8(location))
+			}
+		}
+		presentLetters[i] = summary
+	}
+	notPresentLetters := slices.Clone(self.notPresentLetters)
+	slices.Sort(notPresentLetters)
+
+	otherLetters := maps.Keys(self.excludedLocations)
+	for letter := range self.maxCounts {
+		if _, isPresent := self.presentLetters[letter]; !isPresent && !slices.Contains(otherLetters, letter) {
+			otherLetters = append(otherLetters, letter)
+		}
+	}
+	slices.Sort(otherLetters)
+	var otherSummaries []LetterSummary
+	for _, letter := range otherLetters {
+		summary := LetterSummary{
+			Letter:            letter,
+			ExcludedLocations: slices.Clone(self.excludedLocations[letter]),
+		}
+		if maxCount, hasMax := self.maxCounts[letter]; hasMax {
+			summary.MaxCount = OptionalOf(maxCount)
+		}
+		otherSummaries = append(otherSummaries, summary)
+	}
+	return RestrictionsSummary{
+		WordLength:        self.wordLength,
+		PresentLetters:    presentLetters,
+		NotPresentLetters: notPresentLetters,
+		OtherLetters:      otherSummaries,
+		NoRepeatedLetters: self.noRepeatedLetters,
+	}
+}
+
+// String describes this summary in a compact, human-readable form, such as:
+//
+//	_ R _ _ E | has A (not pos 1,3), exactly one E | no L,S,T
+//
+// The first section shows the known location of each letter. The next describes letters that are
+// present, wherever this adds information beyond the known locations, followed by any other
+// restrictions on letters. Positions start from 1. The last lists letters that are not present.
+// Empty sections are omitted.
+func (s RestrictionsSummary) String() string {
+	pattern := make([]string, s.WordLength)
+	for i := range pattern {
+		pattern[i] = "_"
+	}
+	for _, ls := range s.PresentLetters {
+		for _, location := range ls.KnownLocations {
+			pattern[location] = upperLetter(ls.Letter)
+		}
+	}
+	sections := []string{strings.Join(pattern, " ")}
+
+	var clauses []string
+	for _, ls := range s.PresentLetters {
+		if clause := ls.describe(pattern); clause != "" {
+			clauses = append(clauses, clause)
+		}
+	}
+	for _, ls := range s.OtherLetters {
+		clauses = append(clauses, ls.describeOther())
+	}
+	if s.NoRepeatedLetters {
+		clauses = append(clauses, "no repeated letters")
+	}
+	if len(clauses) > 0 {
+		sections = append(sections, strings.Join(clauses, ", "))
+	}
+
+	if len(s.NotPresentLetters) > 0 {
+		letters := make([]string, len(s.NotPresentLetters))
+		for i, letter := range s.NotPresentLetters {
+			letters[i] = upperLetter(letter)
+		}
+		sections = append(sections, "no "+strings.Join(letters, ","))
+	}
+	return strings.Join(sections, " | ")
+}
+
+// describe returns a description of this letter that adds to the given pattern of known letters,
+// or an empty string if the pattern already says everything that is known.
+func (ls *LetterSummary) describe(pattern []string) string {
+	if !slices.Contains(pattern, "_") {
+		// Every letter is already known.
+		return ""
+	}
+	letter := upperLetter(ls.Letter)
+	// Whether some occurrences of this letter don't have a known location yet.
+	hasUnplaced := ls.MinCount > uint8(len(ls.KnownLocations))
+
+	var count string
+	switch {
+	case ls.ExactCount.HasValue():
+		count = fmt.Sprintf("exactly %s %s", countWord(ls.ExactCount.Value()), letter)
+	case ls.MaxCount.HasValue():
+		count = fmt.Sprintf("%s to %s %s", countWord(ls.MinCount), countWord(ls.MaxCount.Value()), letter)
+	case hasUnplaced && ls.MinCount > 1:
+		count = fmt.Sprintf("at least %s %s", countWord(ls.MinCount), letter)
+	case hasUnplaced:
+		count = "has " + letter
+	default:
+		return ""
+	}
+	if !hasUnplaced {
+		return count
+	}
+	// Only show excluded locations that aren't already filled by a known letter.
+	var excluded []string
+	for _, location := range ls.ExcludedLocations {
+		if pattern[location] == "_" {
+			excluded = append(excluded, fmt.Sprint(location+1))
+		}
+	}
+	if len(excluded) > 0 {
+		return fmt.Sprintf("%s (not pos %s)", count, strings.Join(excluded, ","))
+	}
+	return count
+}
+
+// describeOther returns a description of this letter, which is not known to be in the word.
+func (ls *LetterSummary) describeOther() string {
+	letter := upperLetter(ls.Letter)
+	excluded := make([]string, len(ls.ExcludedLocations))
+	for i, location := range ls.ExcludedLocations {
+		excluded[i] = fmt.Sprint(location + 1)
+	}
+	if !ls.MaxCount.HasValue() {
+		return fmt.Sprintf("no %s at pos %s", letter, strings.Join(excluded, ","))
+	}
+	count := fmt.Sprintf("at most %s %s", countWord(ls.MaxCount.Value()), letter)
+	if len(excluded) > 0 {
+		return fmt.Sprintf("%s (not pos %s)", count, strings.Join(excluded, ","))
+	}
+	return count
+}
+
+func countWord(count uint8) string {
+	switch count {
+	case 1:
+		return "one"
+	case 2:
+		return "two"
+	case 3:
+		return "three"
+	default:
+		return fmt.Sprint(count)
+	}
+}
+
+func upperLetter(letter rune) string {
+	return string(unicode.ToUpper(letter))
+}
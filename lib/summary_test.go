@@ -0,0 +1,75 @@
+package go_wordle_solver
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func restrictionsForGuesses(t *testing.T, objective string, guesses ...string) WordRestrictions {
+	t.Helper()
+	restrictions := InitWordRestrictions(uint8(len(objective)))
+	for _, guess := range guesses {
+		result, err := GetResultForGuess(WordFromString(objective), WordFromString(guess))
+		assert.NilError(t, err)
+		assert.NilError(t, restrictions.Update(&result))
+	}
+	return restrictions
+}
+
+func TestRestrictionsSummary(t *testing.T) {
+	restrictions := restrictionsForGuesses(t, "xrxae", "aster", "lrace", "eerie")
+
+	summary := restrictions.Summary()
+
+	assert.Equal(t, summary.WordLength, uint8(5))
+	assert.DeepEqual(t, summary.NotPresentLetters, []rune{'c', 'i', 'l', 's', 't'})
+	assert.Equal(t, len(summary.PresentLetters), 3)
+	a, e, r := summary.PresentLetters[0], summary.PresentLetters[1], summary.PresentLetters[2]
+	assert.Equal(t, a.Letter, 'a')
+	// The only remaining location for 'a' is deduced.
+	assert.DeepEqual(t, a.KnownLocations, []uint8{3})
+	assert.Equal(t, a.MinCount, uint8(1))
+	assert.Equal(t, e.Letter, 'e')
+	assert.DeepEqual(t, e.KnownLocations, []uint8{4})
+	assert.Equal(t, e.MinCount, uint8(1))
+	assert.Assert(t, e.ExactCount.HasValue())
+	assert.Equal(t, e.ExactCount.Value(), uint8(1))
+	assert.Equal(t, r.Letter, 'r')
+	assert.DeepEqual(t, r.KnownLocations, []uint8{1})
+	// The known locations of other letters are excluded.
+	assert.DeepEqual(t, r.ExcludedLocations, []uint8{2, 3, 4})
+	assert.Equal(t, summary.String(), "_ R _ A E | exactly one A, exactly one E | no C,I,L,S,T")
+}
+
+func TestRestrictionsSummaryIsIndependent(t *testing.T) {
+	restrictions := restrictionsForGuesses(t, "abc", "xyz")
+	summary := restrictions.Summary()
+
+	result, err := GetResultForGuess(WordFromString("abc"), WordFromString("abx"))
+	assert.NilError(t, err)
+	assert.NilError(t, restrictions.Update(&result))
+
+	assert.Equal(t, summary.String(), "_ _ _ | no X,Y,Z")
+	assert.Equal(t, restrictions.Summary().String(), "A B _ | no X,Y,Z")
+}
+
+func TestRestrictionsSummaryString(t *testing.T) {
+	tests := []struct {
+		objective string
+		guesses   []string
+		want      string
+	}{
+		{"abcde", nil, "_ _ _ _ _"},
+		{"abcde", []string{"abcde"}, "A B C D E"},
+		{"aabcd", []string{"xxaaa"}, "A A _ _ _ | exactly two A | no X"},
+		{"aabcd", []string{"xxaax"}, "_ _ _ _ _ | at least two A (not pos 3,4) | no X"},
+		// The remaining locations of 'a' can be deduced.
+		{"aaabc", []string{"xaxaa"}, "A A A _ _ | exactly three A | no X"},
+		{"abcde", []string{"ebxxx"}, "_ B _ _ _ | has E (not pos 1) | no X"},
+	}
+	for _, test := range tests {
+		restrictions := restrictionsForGuesses(t, test.objective, test.guesses...)
+		assert.Equal(t, restrictions.Summary().String(), test.want, "objective: %s, guesses: %v", test.objective, test.guesses)
+	}
+}
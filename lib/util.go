@@ -1,5 +1,10 @@
 package go_wordle_solver
 
+// letter is a constraint for the types that a word's letters may be stored as.
+type letter interface {
+	~byte | ~rune
+}
+
 func fillSlice[T any](s []T, v T) {
 	for i := range s {
 		s[i] = v
@@ -15,6 +20,15 @@ func allValues[T any](s []T, fn func(T) bool) bool {
 	return true
 }
 
+func anyValue[T any](s []T, fn func(T) bool) bool {
+	for _, v := range s {
+		if fn(v) {
+			return true
+		}
+	}
+	return false
+}
+
 func allPairs[K comparable, V any](m map[K]V, fn func(K, V) bool) bool {
 	for k, v := range m {
 		if !fn(k, v) {
@@ -44,3 +58,10 @@ func filter[T any](s []T, fn func(T) bool) []T {
 	}
 	return s[:iNew]
 }
+
+func minInt(a, b int) int {
+	if a < b {
+		return a
+	}
+	return b
+}
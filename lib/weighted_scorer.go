@@ -0,0 +1,356 @@
+package go_wordle_solver
+
+import (
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"math/rand"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"golang.org/x/exp/slices"
+)
+
+// ScoreNormalization determines how a [WeightedScorer] puts the scores from each of its scorers
+// on the same scale before weighting them.
+type ScoreNormalization int
+
+const (
+	// Each score is replaced by the fraction of candidate words that score lower, counting words
+	// with the same score, including the word itself, as half. The normalized scores are between 0
+	// and 1.
+	NormalizeByRank ScoreNormalization = iota
+	// Each score is replaced by the number of standard deviations it is above the mean score of
+	// the candidate words.
+	NormalizeByZScore
+)
+
+// String converts [ScoreNormalization] to a readable string.
+func (n ScoreNormalization) String() string {
+	switch n {
+	case NormalizeByRank:
+		return "rank"
+	case NormalizeByZScore:
+		return "zscore"
+	default:
+		return "invalid ScoreNormalization"
+	}
+}
+
+// ScoreNormalizationFromName returns the normalization with the given name: "rank" or "zscore".
+func ScoreNormalizationFromName(name string) (ScoreNormalization, error) {
+	switch name {
+	case "rank":
+		return NormalizeByRank, nil
+	case "zscore":
+		return NormalizeByZScore, nil
+	default:
+		return 0, fmt.Errorf("Unknown score normalization %q. Expected one of [rank zscore].", name)
+	}
+}
+
+// The factor that weighted scores are multiplied by before they're rounded to an integer.
+const weightedScoreScale = 1e6
+
+// WeightedScorer combines the scores of several [WordScorer]s, by normalizing each score and then
+// summing them with the given weights.
+//
+// Scores are normalized across the candidate words. These are the words that the guesser chooses
+// from, when used with a guesser that sets them (see [CandidateAwareWordScorer]), or otherwise the
+// words in the bank that haven't been guessed yet. Once the candidates change, every candidate is
+// scored by every scorer, either when the candidates are set or the first time a word is scored.
+// The candidates' weighted scores are then cached, so choosing a guess is about as expensive as
+// choosing it with each scorer in turn.
+//
+// Weighted scorers can be built from a string with [ParseWeightedScorer].
+type WeightedScorer struct {
+	bank          *WordBank
+	scorers       []WordScorer
+	weights       []float64
+	normalization ScoreNormalization
+	candidates    PossibleWords
+	// Guards the lazy computation of stats and candidateScores, so that words can be scored
+	// concurrently.
+	mu sync.Mutex
+	// The distribution of the candidates' scores for each scorer, or nil if they haven't been
+	// computed since the last update.
+	stats []scoreStats
+	// The weighted score of each candidate, by word.
+	candidateScores map[string]int64
+}
+
+// Constructs a [WeightedScorer] that combines the given scorers, each multiplied by the weight
+// with the same index.
+//
+// Returns an error if there are no scorers, or if there isn't exactly one weight per scorer.
+func InitWeightedScorer(bank *WordBank, scorers []WordScorer, weights []float64, normalization ScoreNormalization) (WeightedScorer, error) {
+	if len(scorers) == 0 {
+		return WeightedScorer{}, errors.New("A weighted scorer needs at least one scorer.")
+	}
+	if len(scorers) != len(weights) {
+		return WeightedScorer{}, fmt.Errorf("There must be one weight per scorer. Got %v scorers and %v weights.", len(scorers), len(weights))
+	}
+	return WeightedScorer{
+		bank:          bank,
+		scorers:       slices.Clone(scorers),
+		weights:       slices.Clone(weights),
+		normalization: normalization,
+		candidates:    bank.Words(),
+	}, nil
+}
+
+func (self *WeightedScorer) Copy() WordScorer {
+	scorers := make([]WordScorer, len(self.scorers))
+	for i, scorer := range self.scorers {
+		scorers[i] = scorer.Copy()
+	}
+	// The computed scores are never modified, so they can be shared.
+	return &WeightedScorer{
+		self.bank,
+		scorers,
+		self.weights,
+		self.normalization,
+		self.candidates.Copy(),
+		sync.Mutex{},
+		self.stats,
+		self.candidateScores,
+	}
+}
+
+func (self *WeightedScorer) Reset(pw *PossibleWords) {
+	for _, scorer := range self.scorers {
+		scorer.Reset(pw)
+	}
+	self.candidates = self.bank.Words()
+	self.stats = nil
+	self.candidateScores = nil
+}
+
+func (self *WeightedScorer) Update(latestGuess Word, pw *PossibleWords) error {
+	// Each scorer is unchanged if its own update fails, so only the scorers that were already
+	// updated need to be restored.
+	previous := make([]WordScorer, len(self.scorers))
+	for i, scorer := range self.scorers {
+		previous[i] = scorer.Copy()
+		if err := scorer.Update(latestGuess, pw); err != nil {
+			copy(self.scorers, previous[:i])
+			return err
+		}
+	}
+	self.candidates.Remove(latestGuess)
+	self.stats = nil
+	self.candidateScores = nil
+	return nil
+}
+
+// SetCandidates sets the words that scores are normalized across, until the next update or reset,
+// and scores them using up to numThreads goroutines.
+func (self *WeightedScorer) SetCandidates(candidates *PossibleWords, numThreads int) {
+	self.candidates = candidates.Copy()
+	self.scoreCandidates(numThreads)
+}
+
+// IsSafeForConcurrentUse returns true iff all of the combined scorers are safe for concurrent use.
+func (self *WeightedScorer) IsSafeForConcurrentUse() bool {
+	for _, scorer := range self.scorers {
+		if !isSafeForConcurrentUse(scorer) {
+			return false
+		}
+	}
+	return true
+}
+
+func (self *WeightedScorer) ScoreWord(w Word) int64 {
+	self.mu.Lock()
+	if self.stats == nil {
+		self.scoreCandidates(1)
+	}
+	self.mu.Unlock()
+	if score, isPresent := self.candidateScores[w.String()]; isPresent {
+		return score
+	}
+	scores := make([]int64, len(self.scorers))
+	for i, scorer := range self.scorers {
+		scores[i] = scorer.ScoreWord(w)
+	}
+	return self.weightedScore(scores)
+}
+
+// scoreCandidates scores every candidate with each scorer, using up to numThreads goroutines, and
+// caches their weighted scores.
+func (self *WeightedScorer) scoreCandidates(numThreads int) {
+	numCandidates := self.candidates.Len()
+	scores := make([][]int64, numCandidates)
+	for c := range scores {
+		scores[c] = make([]int64, len(self.scorers))
+	}
+	self.stats = make([]scoreStats, len(self.scorers))
+	for s, scorer := range self.scorers {
+		scorerScores := scoreWords(&self.candidates, scorer.ScoreWord, numThreads)
+		for c := range scores {
+			scores[c][s] = scorerScores[c]
+		}
+		self.stats[s] = initScoreStats(scorerScores)
+	}
+	self.candidateScores = make(map[string]int64, numCandidates)
+	for c := range scores {
+		self.candidateScores[self.candidates.At(c).String()] = self.weightedScore(scores[c])
+	}
+}
+
+// weightedScore normalizes the given score from each scorer, and sums them with their weights.
+func (self *WeightedScorer) weightedScore(scores []int64) int64 {
+	total := 0.0
+	for s, score := range scores {
+		total += self.weights[s] * self.stats[s].normalize(score, self.normalization)
+	}
+	return int64(math.Round(total * weightedScoreScale))
+}
+
+// scoreStats describes the distribution of one scorer's scores for the candidate words.
+type scoreStats struct {
+	sorted []int64
+	mean   float64
+	stdDev float64
+}
+
+// initScoreStats computes the distribution of the given scores. This sorts the scores in place.
+func initScoreStats(scores []int64) scoreStats {
+	slices.Sort(scores)
+	stats := scoreStats{sorted: scores}
+	if len(scores) == 0 {
+		return stats
+	}
+	for _, score := range scores {
+		stats.mean += float64(score)
+	}
+	stats.mean /= float64(len(scores))
+	variance := 0.0
+	for _, score := range scores {
+		variance += (float64(score) - stats.mean) * (float64(score) - stats.mean)
+	}
+	stats.stdDev = math.Sqrt(variance / float64(len(scores)))
+	return stats
+}
+
+// normalize normalizes the score relative to the candidates' scores.
+func (self *scoreStats) normalize(score int64, normalization ScoreNormalization) float64 {
+	numScores := len(self.sorted)
+	if numScores == 0 {
+		return 0
+	}
+	if normalization == NormalizeByRank {
+		numLower := sort.Search(numScores, func(i int) bool { return self.sorted[i] >= score })
+		numLowerOrEqual := sort.Search(numScores, func(i int) bool { return self.sorted[i] > score })
+		return (float64(numLower) + float64(numLowerOrEqual-numLower)/2) / float64(numScores)
+	}
+	if self.stdDev == 0 {
+		return 0
+	}
+	return (float64(score) - self.mean) / self.stdDev
+}
+
+// RandomScorer gives each word a random score. The scores are consistent until the next update or
+// reset, so that they can be compared.
+//
+// This is mostly useful in a [WeightedScorer], to break ties or add noise to another scorer.
+type RandomScorer struct {
+	rng  *rand.Rand
+	seed uint64
+}
+
+// Constructs a [RandomScorer].
+func InitRandomScorer() RandomScorer {
+	rng := rand.New(rand.NewSource(time.Now().UnixMicro()))
+	return RandomScorer{rng, rng.Uint64()}
+}
+
+// Copy copies the [RandomScorer]. The copy gives the same scores until it's next updated or reset.
+//
+// The copy's random numbers are seeded from this scorer's, so copies made at the same time still
+// give different scores once they're updated.
+func (self *RandomScorer) Copy() WordScorer {
+	return &RandomScorer{rand.New(rand.NewSource(self.rng.Int63())), self.seed}
+}
+
+func (self *RandomScorer) Reset(pw *PossibleWords) {
+	self.seed = self.rng.Uint64()
+}
+
+func (self *RandomScorer) Update(latestGuess Word, pw *PossibleWords) error {
+	self.seed = self.rng.Uint64()
+	return nil
+}
+
+// IsSafeForConcurrentUse returns true, since the scores only depend on the current seed.
+func (self *RandomScorer) IsSafeForConcurrentUse() bool {
+	return true
+}
+
+func (self *RandomScorer) ScoreWord(w Word) int64 {
+	hash := fnv.New64a()
+	var seed [8]byte
+	for i := range seed {
+		seed[i] = byte(self.seed >> (8 * i))
+	}
+	hash.Write(seed[:])
+	hash.Write([]byte(w.String()))
+	return int64(hash.Sum64() >> 1)
+}
+
+// ScorerNames returns the names of the scorers that can be used with [ParseWeightedScorer].
+func ScorerNames() []string {
+	return []string{"eliminations", "located_letters", "letter_frequency", "random"}
+}
+
+// ParseWeightedScorer constructs a [WeightedScorer] for the given bank from a comma-separated list
+// of scorers and their weights, e.g. "eliminations:1,random:0.1". The weight may be omitted, in
+// which case it's 1. See [ScorerNames] for the available scorers.
+//
+// **Be careful, this is expensive to compute if it includes "eliminations"!** See
+// [InitMaxEliminationsScorer].
+func ParseWeightedScorer(bank *WordBank, spec string, normalization ScoreNormalization) (WeightedScorer, error) {
+	var scorers []WordScorer
+	var weights []float64
+	for _, part := range strings.Split(spec, ",") {
+		name, weightStr, hasWeight := strings.Cut(strings.TrimSpace(part), ":")
+		weight := 1.0
+		if hasWeight {
+			var err error
+			weight, err = strconv.ParseFloat(weightStr, 64)
+			if err != nil || math.IsNaN(weight) || math.IsInf(weight, 0) {
+				return WeightedScorer{}, fmt.Errorf("Invalid weight %q for scorer %s.", weightStr, name)
+			}
+		}
+		scorer, err := scorerFromName(bank, name)
+		if err != nil {
+			return WeightedScorer{}, err
+		}
+		scorers = append(scorers, scorer)
+		weights = append(weights, weight)
+	}
+	return InitWeightedScorer(bank, scorers, weights, normalization)
+}
+
+func scorerFromName(bank *WordBank, name string) (WordScorer, error) {
+	switch name {
+	case "eliminations":
+		scorer, err := InitMaxEliminationsScorer(bank)
+		return &scorer, err
+	case "located_letters":
+		scorer := InitLocatedLettersScorer(bank)
+		return &scorer, nil
+	case "letter_frequency":
+		scorer := InitLetterFrequencyScorer(bank)
+		return &scorer, nil
+	case "random":
+		scorer := InitRandomScorer()
+		return &scorer, nil
+	default:
+		return nil, fmt.Errorf("Unknown scorer %q. Expected one of %v.", name, ScorerNames())
+	}
+}
@@ -0,0 +1,293 @@
+package go_wordle_solver
+
+import (
+	"errors"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+// fixedScorer gives each word a fixed score, and counts its updates and resets. If updateErr is
+// set, updates fail with it.
+type fixedScorer struct {
+	scores     map[string]int64
+	numUpdates int
+	numResets  int
+	updateErr  error
+}
+
+func (self *fixedScorer) Copy() WordScorer {
+	copied := *self
+	return &copied
+}
+
+func (self *fixedScorer) Reset(pw *PossibleWords) {
+	self.numResets++
+}
+
+func (self *fixedScorer) Update(latestGuess Word, pw *PossibleWords) error {
+	if self.updateErr != nil {
+		return self.updateErr
+	}
+	self.numUpdates++
+	return nil
+}
+
+func (self *fixedScorer) ScoreWord(w Word) int64 {
+	return self.scores[w.String()]
+}
+
+func TestWeightedScorerNormalizeByRank(t *testing.T) {
+	bank, err := WordBankFromSlice([]string{"abc", "abd", "abe", "abf"})
+	assert.NilError(t, err)
+	first := fixedScorer{scores: map[string]int64{"abc": 1, "abd": 20, "abe": 20, "abf": 300}}
+	second := fixedScorer{scores: map[string]int64{"abc": 4, "abd": 3, "abe": 2, "abf": 1}}
+
+	scorer, err := InitWeightedScorer(&bank, []WordScorer{&first, &second}, []float64{1, 0.5}, NormalizeByRank)
+	assert.NilError(t, err)
+
+	// Each word ties with itself. The first scorer's ranks are 0.125, 0.5, 0.5 and 0.875, and the
+	// second's are 0.875, 0.625, 0.375 and 0.125.
+	assert.Equal(t, scorer.ScoreWord(WordFromString("abc")), int64(562500))
+	assert.Equal(t, scorer.ScoreWord(WordFromString("abd")), int64(812500))
+	assert.Equal(t, scorer.ScoreWord(WordFromString("abe")), int64(687500))
+	assert.Equal(t, scorer.ScoreWord(WordFromString("abf")), int64(937500))
+	// Other words are ranked against the candidates.
+	assert.Equal(t, scorer.ScoreWord(WordFromString("xyz")), int64(0))
+}
+
+func TestWeightedScorerNormalizeByZScore(t *testing.T) {
+	bank, err := WordBankFromSlice([]string{"abc", "abd", "abe", "abf"})
+	assert.NilError(t, err)
+	first := fixedScorer{scores: map[string]int64{"abc": 1, "abd": 3, "abe": 3, "abf": 5}}
+	same := fixedScorer{scores: map[string]int64{"abc": 7, "abd": 7, "abe": 7, "abf": 7}}
+
+	scorer, err := InitWeightedScorer(&bank, []WordScorer{&first, &same}, []float64{2, 1}, NormalizeByZScore)
+	assert.NilError(t, err)
+
+	// The mean is 3, and the standard deviation is √2. Equal scores are all normalized to zero.
+	assert.Equal(t, scorer.ScoreWord(WordFromString("abc")), int64(-2828427))
+	assert.Equal(t, scorer.ScoreWord(WordFromString("abd")), int64(0))
+	assert.Equal(t, scorer.ScoreWord(WordFromString("abf")), int64(2828427))
+	assert.Equal(t, scorer.ScoreWord(WordFromString("xyz")), int64(-4242641))
+}
+
+func TestWeightedScorerUpdateAndReset(t *testing.T) {
+	bank, err := WordBankFromSlice([]string{"abc", "abd", "abe"})
+	assert.NilError(t, err)
+	first := fixedScorer{scores: map[string]int64{"abc": 1, "abd": 2, "abe": 3}}
+	second := fixedScorer{scores: map[string]int64{"abc": 1, "abd": 2, "abe": 3}}
+	scorer, err := InitWeightedScorer(&bank, []WordScorer{&first, &second}, []float64{1, 1}, NormalizeByRank)
+	assert.NilError(t, err)
+	assert.Equal(t, scorer.ScoreWord(WordFromString("abd")), int64(1000000))
+	copied := scorer.Copy()
+	pw := bank.Words()
+	result := guessResult(t, "abe", "gg-")
+	assert.NilError(t, pw.Filter(&result))
+
+	assert.NilError(t, scorer.Update(result.Guess, &pw))
+
+	assert.Equal(t, first.numUpdates, 1)
+	assert.Equal(t, second.numUpdates, 1)
+	// The guess is no longer a candidate.
+	assert.Equal(t, scorer.ScoreWord(WordFromString("abd")), int64(1500000))
+	assert.Equal(t, copied.ScoreWord(WordFromString("abd")), int64(1000000))
+
+	pw = bank.Words()
+	scorer.Reset(&pw)
+
+	assert.Equal(t, first.numResets, 1)
+	assert.Equal(t, second.numResets, 1)
+	assert.Equal(t, scorer.ScoreWord(WordFromString("abd")), int64(1000000))
+}
+
+func TestWeightedScorerUpdateFailureLeavesScorerUnchanged(t *testing.T) {
+	bank, err := WordBankFromSlice([]string{"abc", "abd", "abe"})
+	assert.NilError(t, err)
+	first := fixedScorer{scores: map[string]int64{"abc": 1, "abd": 2, "abe": 3}}
+	failing := fixedScorer{scores: map[string]int64{"abc": 1, "abd": 2, "abe": 3}, updateErr: errors.New("Nope.")}
+	scorer, err := InitWeightedScorer(&bank, []WordScorer{&first, &failing}, []float64{1, 1}, NormalizeByRank)
+	assert.NilError(t, err)
+	pw := bank.Words()
+	result := guessResult(t, "abe", "gg-")
+	assert.NilError(t, pw.Filter(&result))
+
+	assert.Error(t, scorer.Update(result.Guess, &pw), "Nope.")
+
+	assert.Equal(t, scorer.scorers[0].(*fixedScorer).numUpdates, 0)
+	assert.Equal(t, scorer.candidates.Len(), 3)
+	assert.Equal(t, scorer.ScoreWord(WordFromString("abd")), int64(1000000))
+}
+
+func TestWeightedScorerSetCandidates(t *testing.T) {
+	bank, err := WordBankFromSlice([]string{"abc", "abd", "abe", "abf"})
+	assert.NilError(t, err)
+	first := fixedScorer{scores: map[string]int64{"abc": 1, "abd": 2, "abe": 3, "abf": 4}}
+	scorer, err := InitWeightedScorer(&bank, []WordScorer{&first}, []float64{1}, NormalizeByRank)
+	assert.NilError(t, err)
+	assert.Equal(t, scorer.ScoreWord(WordFromString("abd")), int64(375000))
+	candidates, err := WordBankFromSlice([]string{"abd", "abe"})
+	assert.NilError(t, err)
+	candidateWords := candidates.Words()
+
+	scorer.SetCandidates(&candidateWords, 1)
+
+	assert.Equal(t, scorer.ScoreWord(WordFromString("abd")), int64(250000))
+	assert.Equal(t, scorer.ScoreWord(WordFromString("abe")), int64(750000))
+	assert.Equal(t, scorer.ScoreWord(WordFromString("abf")), int64(1000000))
+}
+
+func TestWeightedScorerSetCandidatesInParallel(t *testing.T) {
+	bank := firstWordsBank(t, 300)
+	words := bank.Words()
+	serial, err := ParseWeightedScorer(&bank, "located_letters,letter_frequency:0.5", NormalizeByZScore)
+	assert.NilError(t, err)
+	parallel := serial.Copy().(*WeightedScorer)
+
+	serial.SetCandidates(&words, 1)
+	parallel.SetCandidates(&words, 4)
+
+	for i := 0; i < words.Len(); i++ {
+		assert.Equal(t, parallel.ScoreWord(words.At(i)), serial.ScoreWord(words.At(i)), words.At(i).String())
+	}
+}
+
+func TestMaxScoreGuesserSetsWeightedScorerCandidates(t *testing.T) {
+	bank, err := WordBankFromSlice([]string{"abc", "abd", "abe", "xyz"})
+	assert.NilError(t, err)
+	first := fixedScorer{scores: map[string]int64{"abc": 1, "abd": 2, "abe": 3, "xyz": 4}}
+	scorer, err := InitWeightedScorer(&bank, []WordScorer{&first}, []float64{1}, NormalizeByRank)
+	assert.NilError(t, err)
+	guesser := InitMaxScoreGuesser(&bank, &scorer, GuessModePossible)
+	result := guessResult(t, "xyz", "---")
+	assert.NilError(t, guesser.Update(&result))
+
+	guess := guesser.SelectNextGuess()
+
+	assert.Equal(t, guess.Value().String(), "abe")
+	assert.DeepEqual(t, wordStrings(scorer.candidates.words), []string{"abc", "abd", "abe"})
+}
+
+func TestInitWeightedScorerErrors(t *testing.T) {
+	bank, err := WordBankFromSlice([]string{"abc", "abd"})
+	assert.NilError(t, err)
+	random := InitRandomScorer()
+
+	_, err = InitWeightedScorer(&bank, nil, nil, NormalizeByRank)
+	assert.Error(t, err, "A weighted scorer needs at least one scorer.")
+	_, err = InitWeightedScorer(&bank, []WordScorer{&random}, []float64{1, 2}, NormalizeByRank)
+	assert.Error(t, err, "There must be one weight per scorer. Got 1 scorers and 2 weights.")
+}
+
+func TestRandomScorer(t *testing.T) {
+	scorer := InitRandomScorer()
+	words := []Word{WordFromString("abc"), WordFromString("abd"), WordFromString("abe")}
+	scores := make([]int64, len(words))
+	for i, word := range words {
+		scores[i] = scorer.ScoreWord(word)
+		assert.Assert(t, scores[i] >= 0)
+	}
+	copied := scorer.Copy()
+
+	for i, word := range words {
+		assert.Equal(t, scorer.ScoreWord(word), scores[i])
+		assert.Equal(t, copied.ScoreWord(word), scores[i])
+	}
+	// Copies made at the same time diverge once they're updated.
+	other := scorer.Copy()
+	assert.NilError(t, copied.Update(words[0], nil))
+	assert.NilError(t, other.Update(words[0], nil))
+	numDifferent := 0
+	for _, word := range words {
+		if copied.ScoreWord(word) != other.ScoreWord(word) {
+			numDifferent++
+		}
+	}
+	assert.Assert(t, numDifferent > 0)
+
+	assert.NilError(t, scorer.Update(words[0], nil))
+	numChanged := 0
+	for i, word := range words {
+		if scorer.ScoreWord(word) != scores[i] {
+			numChanged++
+		}
+	}
+	assert.Assert(t, numChanged > 0)
+}
+
+func TestParseWeightedScorer(t *testing.T) {
+	bank, err := WordBankFromSlice([]string{"abc", "abd", "xyz"})
+	assert.NilError(t, err)
+
+	scorer, err := ParseWeightedScorer(&bank, "eliminations:1, located_letters, random:0.1", NormalizeByZScore)
+
+	assert.NilError(t, err)
+	assert.Equal(t, len(scorer.scorers), 3)
+	assert.DeepEqual(t, scorer.weights, []float64{1, 1, 0.1})
+	assert.Equal(t, scorer.normalization, NormalizeByZScore)
+	_, isEliminations := scorer.scorers[0].(*MaxEliminationsScorer)
+	assert.Assert(t, isEliminations)
+	_, isLocated := scorer.scorers[1].(*LocatedLettersScorer)
+	assert.Assert(t, isLocated)
+	_, isRandom := scorer.scorers[2].(*RandomScorer)
+	assert.Assert(t, isRandom)
+}
+
+func TestParseWeightedScorerErrors(t *testing.T) {
+	bank, err := WordBankFromSlice([]string{"abc", "abd"})
+	assert.NilError(t, err)
+
+	_, err = ParseWeightedScorer(&bank, "random:lots", NormalizeByRank)
+	assert.Error(t, err, `Invalid weight "lots" for scorer random.`)
+	_, err = ParseWeightedScorer(&bank, "random:1,magic:2", NormalizeByRank)
+	assert.Error(t, err, `Unknown scorer "magic". Expected one of [eliminations located_letters letter_frequency random].`)
+	_, err = ParseWeightedScorer(&bank, "", NormalizeByRank)
+	assert.Error(t, err, `Unknown scorer "". Expected one of [eliminations located_letters letter_frequency random].`)
+}
+
+func TestScoreNormalizationFromName(t *testing.T) {
+	for _, normalization := range []ScoreNormalization{NormalizeByRank, NormalizeByZScore} {
+		got, err := ScoreNormalizationFromName(normalization.String())
+		assert.NilError(t, err)
+		assert.Equal(t, got, normalization)
+	}
+	_, err := ScoreNormalizationFromName("minmax")
+	assert.Error(t, err, `Unknown score normalization "minmax". Expected one of [rank zscore].`)
+}
+
+func TestPlayGameWithWeightedScorer(t *testing.T) {
+	bank := firstWordsBank(t, 300)
+	words := bank.Words()
+	scorer, err := ParseWeightedScorer(&bank, "located_letters:1,letter_frequency:0.5,random:0.1", NormalizeByRank)
+	assert.NilError(t, err)
+	guesser := InitMaxScoreGuesser(&bank, &scorer, GuessModeAll)
+
+	for i := 0; i < words.Len(); i += 30 {
+		result, err := PlayGameWithGuesser(words.At(i), 20, &guesser)
+
+		assert.NilError(t, err)
+		assert.Equal(t, result.Status, GameSuccess, words.At(i).String())
+	}
+}
+
+func TestWeightedScorerIsSafeForConcurrentUse(t *testing.T) {
+	bank := firstWordsBank(t, 300)
+	words := bank.Words()
+	safe, err := ParseWeightedScorer(&bank, "located_letters,random:0.1", NormalizeByRank)
+	assert.NilError(t, err)
+	random := InitRandomScorer()
+	unsafe, err := InitWeightedScorer(&bank, []WordScorer{&random, &fixedScorer{}}, []float64{1, 1}, NormalizeByRank)
+	assert.NilError(t, err)
+
+	assert.Assert(t, safe.IsSafeForConcurrentUse())
+	assert.Assert(t, !unsafe.IsSafeForConcurrentUse())
+
+	guesser := InitMaxScoreGuesser(&bank, &safe, GuessModeAll)
+	guesser.SetNumThreads(4)
+	for i := 0; i < words.Len(); i += 60 {
+		result, err := PlayGameWithGuesser(words.At(i), 20, &guesser)
+
+		assert.NilError(t, err)
+		assert.Equal(t, result.Status, GameSuccess, words.At(i).String())
+	}
+}
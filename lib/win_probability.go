@@ -0,0 +1,214 @@
+package go_wordle_solver
+
+// A BudgetAwareGuesser is a [Guesser] that can make use of the number of guesses it has left.
+//
+// [PlayGameWithGuesser] tells such guessers how many guesses remain before each guess, based on
+// its maxNumGuesses.
+type BudgetAwareGuesser interface {
+	Guesser
+
+	// SetRemainingGuesses sets the number of guesses left in the game, including the next guess.
+	//
+	// A value of zero means that the number of guesses is unknown. This is reset to zero by
+	// [Guesser.Reset].
+	SetRemainingGuesses(numGuesses int)
+}
+
+// The largest number of possible words for which [WinProbabilityGuesser] searches exactly for the
+// best guess.
+const maxWordsForExactSearch = 50
+
+// WinProbabilityGuesser chooses the guess that maximizes the probability of solving the puzzle
+// within the remaining number of guesses, assuming each possible word is equally likely.
+//
+// The number of remaining guesses must be set with [WinProbabilityGuesser.SetRemainingGuesses],
+// which [PlayGameWithGuesser] does automatically. The best guess is found as follows:
+//
+//   - With one guess left, this guesses the possible word with the best score.
+//   - With two guesses left, this chooses the guess that splits the possible words into the most
+//     groups, since each group then has one chance of being guessed.
+//   - With more guesses left and at most 50 possible words, this searches exactly for the best
+//     guess among the possible words and the 30 other words that split them into the most groups.
+//   - Otherwise, including when the number of guesses is unknown, this guesses like a
+//     [MaxScoreGuesser] with the given [WordScorer].
+//
+// Ties are broken in favour of possible words, and then by the score.
+//
+// When benchmarked against the words in `data/1000-improved-words-shuffled.txt`, with the same list
+// as the word bank, the [MaxEliminationsScorer], and only 3 guesses for each word, this failed 260
+// games where [MaxScoreGuesser] failed 280. With 4 guesses, this failed 2 games where
+// [MaxScoreGuesser] failed 20. With `data/improved-words.txt` as the word bank and 3 guesses, this
+// failed 538 games where [MaxScoreGuesser] failed 592, or 669 with its endgame search disabled.
+type WinProbabilityGuesser[S WordScorer] struct {
+	bank             *WordBank
+	possibleWords    PossibleWords
+	scorer           S
+	guessMode        GuessMode
+	unguessedWords   PossibleWords
+	remainingGuesses int
+}
+
+// InitWinProbabilityGuesser constructs a [WinProbabilityGuesser] for the given bank, scorer and
+// mode.
+func InitWinProbabilityGuesser[S WordScorer](bank *WordBank, scorer S, mode GuessMode) WinProbabilityGuesser[S] {
+	return WinProbabilityGuesser[S]{
+		bank:           bank,
+		possibleWords:  bank.Words(),
+		scorer:         scorer,
+		guessMode:      mode,
+		unguessedWords: bank.Words(),
+	}
+}
+
+// Copy copies the [WinProbabilityGuesser].
+func (self *WinProbabilityGuesser[S]) Copy() Guesser {
+	return &WinProbabilityGuesser[S]{
+		self.bank,
+		self.possibleWords.Copy(),
+		self.scorer.Copy().(S),
+		self.guessMode,
+		self.unguessedWords.Copy(),
+		self.remainingGuesses,
+	}
+}
+
+// Reset resets the possible words and the number of remaining guesses, so this can be used to
+// solve a new puzzle.
+func (self *WinProbabilityGuesser[S]) Reset() {
+	self.possibleWords = self.bank.Words()
+	self.unguessedWords = self.bank.Words()
+	self.scorer.Reset(&self.possibleWords)
+	self.remainingGuesses = 0
+}
+
+// SetRemainingGuesses sets the number of guesses left, including the next guess.
+func (self *WinProbabilityGuesser[S]) SetRemainingGuesses(numGuesses int) {
+	self.remainingGuesses = numGuesses
+}
+
+// Update updates the current possible words based on the given result.
+//
+// If the result conflicts with earlier results, this returns an error and the guesser is unchanged.
+func (self *WinProbabilityGuesser[S]) Update(result *GuessResult) error {
+	previous := self.possibleWords.Copy()
+	err := self.possibleWords.Filter(result)
+	if err != nil {
+		return err
+	}
+	if err := self.scorer.Update(result.Guess, &self.possibleWords); err != nil {
+		self.possibleWords = previous
+		return err
+	}
+	self.unguessedWords.Remove(result.Guess)
+	return nil
+}
+
+// SelectNextGuess returns the guess that is most likely to solve the puzzle within the remaining
+// guesses.
+//
+// If there are no more possible words, this returns an empty optional.
+func (self *WinProbabilityGuesser[S]) SelectNextGuess() Optional[Word] {
+	numPossible := self.possibleWords.Len()
+	switch {
+	case numPossible == 0:
+		return Optional[Word]{}
+	case numPossible <= 2:
+		// Guessing either word solves the puzzle within two guesses.
+		return OptionalOf(self.possibleWords.At(0))
+	case self.remainingGuesses == 1:
+		return OptionalOf(self.possibleWords.Maximizing(self.scorer.ScoreWord))
+	case self.remainingGuesses == 2:
+		guesses := self.candidateGuesses()
+		return OptionalOf(selectBestGuess(&self.possibleWords, guesses, self.scorer.ScoreWord, func(guess int) int {
+			return numWinsWithinTwo(guesses.At(guess), &self.possibleWords)
+		}))
+	case !canSearchExactly(&self.possibleWords):
+		// Fall back to the scorer.
+	case self.remainingGuesses == 0 && numPossible <= DefaultEndgameThreshold:
+		return OptionalOf(selectEndgameGuess(&self.possibleWords, &self.unguessedWords, self.guessMode, self.scorer.ScoreWord))
+	case self.remainingGuesses > 2 && numPossible <= maxWordsForExactSearch:
+		search := winSearch{
+			initResultTable(&self.possibleWords, &self.unguessedWords, self.guessMode),
+			make(map[string]int),
+		}
+		words := allPossibleIndices(numPossible)
+		return OptionalOf(selectBestGuess(&self.possibleWords, &search.guesses, self.scorer.ScoreWord, func(guess int) int {
+			return search.numWinsAfterGuess(guess, words, self.remainingGuesses)
+		}))
+	}
+	setScorerCandidates(self.scorer, maxScoreCandidates(&self.possibleWords, &self.unguessedWords, self.guessMode), 1)
+	return selectMaxScoreGuess(&self.possibleWords, &self.unguessedWords, self.guessMode, self.scorer.ScoreWord, 1)
+}
+
+// PossibleWords provides a pointer to the possible words for this guesser.
+//
+// This remains valid until [WinProbabilityGuesser.Reset] is called.
+func (self *WinProbabilityGuesser[S]) PossibleWords() *PossibleWords {
+	return &self.possibleWords
+}
+
+// candidateGuesses returns the words that may be guessed, based on the guess mode.
+func (self *WinProbabilityGuesser[S]) candidateGuesses() *PossibleWords {
+	if self.guessMode == GuessModeAll && self.unguessedWords.Len() > 0 {
+		return &self.unguessedWords
+	}
+	return &self.possibleWords
+}
+
+// winSearch searches exactly for the number of possible words that can be solved within a number
+// of guesses.
+type winSearch struct {
+	resultTable
+	// The number of wins for sets of possible words with a number of guesses.
+	memo map[string]int
+}
+
+// numWins returns the most possible words out of words that can be solved within numGuesses.
+func (self *winSearch) numWins(words []int32, numGuesses int) int {
+	switch {
+	case len(words) == 0 || numGuesses <= 0:
+		return 0
+	case numGuesses >= len(words):
+		// Guessing each word in turn solves them all.
+		return len(words)
+	case numGuesses == 1:
+		return 1
+	}
+	key := memoKey(words, numGuesses)
+	if wins, isPresent := self.memo[key]; isPresent {
+		return wins
+	}
+	best := 0
+	for g := range self.guesses.words {
+		wins := self.numWinsAfterGuess(g, words, numGuesses)
+		if wins > best {
+			best = wins
+			if best == len(words) {
+				break
+			}
+		}
+	}
+	self.memo[key] = best
+	return best
+}
+
+// numWinsAfterGuess returns the most possible words out of words that can be solved within
+// numGuesses, if the given guess is made first.
+func (self *winSearch) numWinsAfterGuess(guess int, words []int32, numGuesses int) int {
+	wins := 0
+	isPossible, groups := self.groupByResult(guess, words)
+	if isPossible {
+		wins++
+	}
+	if numGuesses == 2 {
+		return wins + len(groups)
+	}
+	// A guess that doesn't split the words is never useful.
+	if !isPossible && len(groups) == 1 {
+		return 0
+	}
+	for _, group := range groups {
+		wins += self.numWins(group, numGuesses-1)
+	}
+	return wins
+}
@@ -0,0 +1,179 @@
+package go_wordle_solver
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+// budgetRecordingGuesser records the remaining guesses it's given before each guess.
+type budgetRecordingGuesser struct {
+	*RandomGuesser
+	remaining []int
+}
+
+func (self *budgetRecordingGuesser) SetRemainingGuesses(numGuesses int) {
+	self.remaining = append(self.remaining, numGuesses)
+}
+
+func TestPlayGameWithGuesserSetsRemainingGuesses(t *testing.T) {
+	bank, err := WordBankFromSlice([]string{"abc", "def", "ghi", "jkl"})
+	assert.NilError(t, err)
+	random := InitRandomGuesser(&bank)
+	guesser := budgetRecordingGuesser{RandomGuesser: &random}
+
+	result, err := PlayGameWithGuesser(WordFromString("jkl"), 6, &guesser)
+
+	assert.NilError(t, err)
+	assert.Equal(t, result.Status, GameSuccess)
+	want := []int{6, 5, 4, 3}[:len(result.Turns)]
+	assert.DeepEqual(t, guesser.remaining, want)
+}
+
+func TestWinSearchMatchesBruteForce(t *testing.T) {
+	var words []string
+	for _, w := range allWords("abcd", 3) {
+		if len(words) < 14 && w.At(0) != w.At(2) {
+			words = append(words, w.String())
+		}
+	}
+	bank, err := WordBankFromSlice(words)
+	assert.NilError(t, err)
+	possibleWords := bank.Words()
+	search := winSearch{initResultTable(&possibleWords, &possibleWords, GuessModePossible), make(map[string]int)}
+	all := allPossibleIndices(len(words))
+
+	for numGuesses := 0; numGuesses <= 4; numGuesses++ {
+		assert.Equal(t, search.numWins(all, numGuesses), bruteForceNumWins(&search, all, numGuesses), numGuesses)
+	}
+}
+
+// bruteForceNumWins tries every guess at every level, without any shortcuts.
+func bruteForceNumWins(search *winSearch, words []int32, numGuesses int) int {
+	if len(words) == 0 || numGuesses == 0 {
+		return 0
+	}
+	best := 0
+	for g := range search.guesses.words {
+		wins := 0
+		groups := make(map[CompressedGuessResult64][]int32)
+		for _, w := range words {
+			if w == search.possibleIndex[g] {
+				wins++
+				continue
+			}
+			groups[search.results[g][w]] = append(groups[search.results[g][w]], w)
+		}
+		for _, group := range groups {
+			if len(group) < len(words) {
+				wins += bruteForceNumWins(search, group, numGuesses-1)
+			}
+		}
+		if wins > best {
+			best = wins
+		}
+	}
+	return best
+}
+
+func TestWinProbabilityGuesserWithUnknownBudgetMatchesMaxScore(t *testing.T) {
+	bank, err := BuiltinWordBank("1000-improved-shuffled")
+	assert.NilError(t, err)
+	scorer, err := InitMaxEliminationsScorer(&bank)
+	assert.NilError(t, err)
+	maxScore := InitMaxScoreGuesser(&bank, &scorer, GuessModeAll)
+	winProbability := InitWinProbabilityGuesser(&bank, &scorer, GuessModeAll)
+	result := guessResult(t, "tares", "-y--y")
+	assert.NilError(t, maxScore.Update(&result))
+	assert.NilError(t, winProbability.Update(&result))
+
+	got := winProbability.SelectNextGuess()
+	want := maxScore.SelectNextGuess()
+	assert.Equal(t, got.Value().String(), want.Value().String())
+}
+
+func TestWinProbabilityGuesserFailsLessOften(t *testing.T) {
+	bank, err := BuiltinWordBank("1000-improved-shuffled")
+	assert.NilError(t, err)
+	scorer, err := InitMaxEliminationsScorer(&bank)
+	assert.NilError(t, err)
+	maxScore := InitMaxScoreGuesser(&bank, &scorer, GuessModeAll)
+	// Only the first 150 words are played, so compare against the greedy guesser.
+	maxScore.SetEndgameThreshold(0)
+	winProbability := InitWinProbabilityGuesser(&bank, &scorer, GuessModeAll)
+	words := bank.Words()
+
+	numFailures := func(guesser Guesser) int {
+		failures := 0
+		for i := 0; i < 150; i++ {
+			result, err := PlayGameWithGuesser(words.At(i), 3, guesser)
+			assert.NilError(t, err)
+			if result.Status == GameFailure {
+				failures++
+			}
+		}
+		return failures
+	}
+
+	maxScoreFailures := numFailures(&maxScore)
+	winProbabilityFailures := numFailures(&winProbability)
+	assert.Assert(t, winProbabilityFailures < maxScoreFailures, "%v vs %v", winProbabilityFailures, maxScoreFailures)
+}
+
+func TestWinProbabilityGuesserFailsLessOftenOnEveryWord(t *testing.T) {
+	bank := firstWordsBank(t, 300)
+	scorer, err := InitMaxEliminationsScorer(&bank)
+	assert.NilError(t, err)
+	maxScore := InitMaxScoreGuesser(&bank, &scorer, GuessModeAll)
+	winProbability := InitWinProbabilityGuesser(&bank, &scorer, GuessModeAll)
+	words := bank.Words()
+
+	// Every word is played, so this can't do worse when the first guess is the same.
+	numFailures := func(guesser Guesser) int {
+		failures := 0
+		for i := 0; i < words.Len(); i++ {
+			result, err := PlayGameWithGuesser(words.At(i), 3, guesser)
+			assert.NilError(t, err)
+			if result.Status == GameFailure {
+				failures++
+			}
+		}
+		return failures
+	}
+
+	maxScoreFailures := numFailures(&maxScore)
+	winProbabilityFailures := numFailures(&winProbability)
+	assert.Assert(t, winProbabilityFailures < maxScoreFailures, "%v vs %v", winProbabilityFailures, maxScoreFailures)
+}
+
+// firstWordsBank returns a bank of the first words in 1000-improved-shuffled.
+func firstWordsBank(t *testing.T, numWords int) WordBank {
+	full, err := BuiltinWordBank("1000-improved-shuffled")
+	assert.NilError(t, err)
+	words := full.Words()
+	bankWords := make([]string, numWords)
+	for i := range bankWords {
+		bankWords[i] = words.At(i).String()
+	}
+	bank, err := WordBankFromSlice(bankWords)
+	assert.NilError(t, err)
+	return bank
+}
+
+func TestWinProbabilityGuesserCopyIsIndependent(t *testing.T) {
+	bank, err := WordBankFromSlice([]string{"abc", "abd", "abe", "xyz"})
+	assert.NilError(t, err)
+	scorer, err := InitMaxEliminationsScorer(&bank)
+	assert.NilError(t, err)
+	guesser := InitWinProbabilityGuesser(&bank, &scorer, GuessModeAll)
+	guesser.SetRemainingGuesses(3)
+	copied := guesser.Copy()
+	result := guessResult(t, "xyz", "---")
+
+	assert.NilError(t, copied.Update(&result))
+
+	assert.Equal(t, copied.PossibleWords().Len(), 3)
+	assert.Equal(t, guesser.PossibleWords().Len(), 4)
+	guesser.Reset()
+	assert.Equal(t, guesser.remainingGuesses, 0)
+}
@@ -1,41 +1,175 @@
 package go_wordle_solver
 
-import "golang.org/x/exp/slices"
+import "fmt"
 
 // A Word represents a read-only string, optimized to work with runes instead of bytes.
+//
+// Short ASCII words (up to [MaxPackedWordLength] letters) are stored in a packed form that avoids
+// heap allocations entirely, and that enables faster comparisons and feedback calculations. All
+// other words are stored as a slice of runes. The representation is chosen automatically by
+// [WordFromString], so a [WordBank] of short ASCII words is always fully packed.
 type Word struct {
+	// The letters of this word, or nil if the word is packed.
 	runes []rune
+	// The packed form of this word. Only valid if runes is nil.
+	packed packedWord
+}
+
+// MaxPackedWordLength is the maximum number of letters in a word that can use the packed
+// representation.
+const MaxPackedWordLength int = 8
+
+// A packedWord stores up to [MaxPackedWordLength] ASCII letters in a single integer, using 7 bits
+// per letter. The top byte stores the number of letters in the word.
+type packedWord uint64
+
+const (
+	packedBitsPerLetter = 7
+	packedLetterMask    = 1<<packedBitsPerLetter - 1
+	packedLengthShift   = 56
+	maxPackedLetter     = 0x7f
+)
+
+const (
+	// Has the lowest bit of every letter set.
+	packedLowBits packedWord = 0x0081020408102040 >> 6
+	// Has the highest bit of every letter set.
+	packedHighBits packedWord = packedLowBits << (packedBitsPerLetter - 1)
+	// Has all but the highest bit of every letter set.
+	packedLowerMask packedWord = packedLowBits * (packedLetterMask >> 1)
+)
+
+// tryPackWord packs the given string if possible.
+//
+// Returns false if the string is too long or contains non-ASCII characters.
+func tryPackWord(s string) (packedWord, bool) {
+	if len(s) > MaxPackedWordLength {
+		return 0, false
+	}
+	var packed packedWord
+	for i := 0; i < len(s); i++ {
+		if s[i] > maxPackedLetter {
+			return 0, false
+		}
+		packed |= packedWord(s[i]) << (i * packedBitsPerLetter)
+	}
+	return packed | packedWord(len(s))<<packedLengthShift, true
+}
+
+func (p packedWord) len() int {
+	return int(p >> packedLengthShift)
+}
+
+func (p packedWord) at(i int) byte {
+	return byte(p>>(i*packedBitsPerLetter)) & packedLetterMask
+}
+
+// broadcastPacked returns a value with every letter slot set to the given letter.
+func broadcastPacked(letter byte) packedWord {
+	return packedWord(letter) * packedLowBits
+}
+
+// matchingLetters returns a mask with the highest bit of each letter slot set iff the letter at
+// that slot equals the corresponding letter in other. The length byte is ignored.
+func (p packedWord) matchingLetters(other packedWord) packedWord {
+	diff := p ^ other
+	// For each slot, the high bit is set after this iff any bit in the slot is set. The addition
+	// cannot overflow into the next slot.
+	nonZero := ((diff & packedLowerMask) + packedLowerMask) | diff
+	return ^nonZero & packedHighBits
+}
+
+// lengthMask returns a mask with the highest bit set for each slot within this word's length.
+func (p packedWord) lengthMask() packedWord {
+	return packedHighBits & (1<<(p.len()*packedBitsPerLetter) - 1)
+}
+
+// unpack writes the letters of this word into the given array, and returns the populated portion
+// of it.
+func (p packedWord) unpack(letters *[MaxPackedWordLength]byte) []byte {
+	length := p.len()
+	for i := 0; i < length; i++ {
+		letters[i] = p.at(i)
+	}
+	return letters[:length]
 }
 
 // WordFromString constructs a word from a given string.
 //
-// This requires allocating a slice, so it's best to convert strings to [Word]s once, and then only
-// use Words from there.
+// Words that are not eligible for the packed representation require allocating a slice, so it's
+// best to convert strings to [Word]s once, and then only use Words from there.
 func WordFromString(s string) Word {
-	return Word{[]rune(s)}
+	if packed, ok := tryPackWord(s); ok {
+		return Word{packed: packed}
+	}
+	return Word{runes: []rune(s)}
+}
+
+// wordFromRunes constructs an unpacked word from the given runes, regardless of whether the word
+// could be packed. This is useful for comparing the two representations.
+func wordFromRunes(runes []rune) Word {
+	return Word{runes: runes}
+}
+
+func (self Word) isPacked() bool {
+	return self.runes == nil
 }
 
 // Equal determines if this word is equal to the given word.
 func (self Word) Equal(w Word) bool {
-	return slices.Compare(self.runes, w.runes) == 0
+	if self.isPacked() && w.isPacked() {
+		return self.packed == w.packed
+	}
+	length := self.Len()
+	if length != w.Len() {
+		return false
+	}
+	for i := 0; i < length; i++ {
+		if self.At(i) != w.At(i) {
+			return false
+		}
+	}
+	return true
 }
 
 // Len returns the number of runes (i.e. letters) in this word.
 func (self Word) Len() int {
+	if self.isPacked() {
+		return self.packed.len()
+	}
 	return len(self.runes)
 }
 
 // String converts this word back to a string.
 func (self Word) String() string {
+	if self.isPacked() {
+		var letters [MaxPackedWordLength]byte
+		return string(self.packed.unpack(&letters))
+	}
 	return string(self.runes)
 }
 
 // At returns the rune (i.e. letter) at the given index in the word.
 func (self Word) At(i int) rune {
+	if self.isPacked() {
+		if length := self.packed.len(); i < 0 || i >= length {
+			panic(fmt.Sprintf("index out of range [%v] with length %v", i, length))
+		}
+		return rune(self.packed.at(i))
+	}
 	return self.runes[i]
 }
 
 // AllLetters determines if all the letters in this word satisfy the given function.
 func (self Word) AllLetters(fn func(rune) bool) bool {
+	if self.isPacked() {
+		length := self.packed.len()
+		for i := 0; i < length; i++ {
+			if !fn(rune(self.packed.at(i))) {
+				return false
+			}
+		}
+		return true
+	}
 	return allValues(self.runes, fn)
 }
@@ -5,73 +5,106 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"strings"
+	"math"
 
 	"golang.org/x/exp/slices"
 )
 
 // WordBank provides a read-only set of equal length words.
+//
+// If the words are short enough and only contain ASCII characters, they will all use the faster,
+// packed [Word] representation.
 type WordBank struct {
 	allWords   []Word
 	wordLength uint8
+	normalizer Normalizer
+	rule       FeedbackRule
 }
 
 const defaultWordBuffer int = 100
 
 // WordBankFromReader constructs a new [WordBank] by reading words from the given reader.
 //
-// The reader should provide one word per line. Each word will be trimmed and converted to
-// lower case. Empty lines are skipped. At least one word must be provided.
+// The reader should provide one word per line. Each word will be normalized with the default
+// [Normalizer], i.e. trimmed and converted to lower case. Empty lines are skipped. At least one
+// word must be provided.
 //
-// After trimming, all words must be the same length, else this returns an error.
+// After trimming, all words must be the same length, else this returns an error. Use
+// [MultiLengthWordBankFromReader] to read words of varying lengths.
 func WordBankFromReader(r io.Reader) (WordBank, error) {
-	s := bufio.NewScanner(r)
+	return WordBankFromReaderWithNormalizer(r, Normalizer{})
+}
+
+// WordBankFromReaderWithNormalizer is the same as [WordBankFromReader], but normalizes words with
+// the given [Normalizer].
+func WordBankFromReaderWithNormalizer(r io.Reader, normalizer Normalizer) (WordBank, error) {
 	words := make([]Word, 0, defaultWordBuffer)
-	n := 0
 	wordLength := 0
-	for ok := s.Scan(); ok; ok = s.Scan() {
-		thisWord := WordFromString(strings.ToLower(strings.TrimSpace(s.Text())))
-		thisWordLength := thisWord.Len()
-		if thisWordLength == 0 {
-			continue
-		}
-		words = append(words, thisWord)
-		if n == 0 {
+	err := scanWords(r, normalizer, func(word Word) error {
+		thisWordLength := word.Len()
+		if len(words) == 0 {
 			wordLength = thisWordLength
 		}
 		if thisWordLength != wordLength {
-			return WordBank{}, fmt.Errorf("Words must all be the same length. Encountered word with length %v when expecting length %v.", thisWordLength, wordLength)
+			return fmt.Errorf("Words must all be the same length. Encountered word with length %v when expecting length %v.", thisWordLength, wordLength)
 		}
-		n++
-	}
-	if err := s.Err(); err != nil {
+		words = append(words, word)
+		return nil
+	})
+	if err != nil {
 		return WordBank{}, err
 	}
 	if len(words) == 0 {
 		return WordBank{}, errors.New("At least one word must be provided.")
 	}
-	return WordBank{slices.Clip(words), uint8(wordLength)}, nil
+	return WordBank{slices.Clip(words), uint8(wordLength), normalizer, WordleRule{}}, nil
+}
+
+// scanWords reads one word per line from the given reader, and calls fn with each word.
+//
+// Each word is normalized with the given normalizer, and empty lines are skipped. Scanning stops
+// at the first error returned by fn.
+func scanWords(r io.Reader, normalizer Normalizer, fn func(Word) error) error {
+	s := bufio.NewScanner(r)
+	for ok := s.Scan(); ok; ok = s.Scan() {
+		thisWord := normalizer.WordFromString(s.Text())
+		if thisWord.Len() == 0 {
+			continue
+		}
+		if err := fn(thisWord); err != nil {
+			return err
+		}
+	}
+	return s.Err()
 }
 
 // WordBankFromSlice constructs a new [WordBank] using the words from the given slice.
 //
-// Each word will be trimmed and converted to lower case. At least one word must be provided.
+// Each word will be normalized with the default [Normalizer], i.e. trimmed and converted to lower
+// case. At least one word must be provided.
 //
 // After trimming, all words must be the same length, else this returns an error.
 func WordBankFromSlice(words []string) (WordBank, error) {
+	return WordBankFromSliceWithNormalizer(words, Normalizer{})
+}
+
+// WordBankFromSliceWithNormalizer is the same as [WordBankFromSlice], but normalizes words with
+// the given [Normalizer].
+func WordBankFromSliceWithNormalizer(words []string, normalizer Normalizer) (WordBank, error) {
 	if len(words) == 0 {
 		return WordBank{}, errors.New("At least one word must be provided.")
 	}
-	wordLength := len(words[0])
 	allWords := make([]Word, len(words))
 	for i, wordStr := range words {
-		word := WordFromString(strings.ToLower(strings.TrimSpace(wordStr)))
+		allWords[i] = normalizer.WordFromString(wordStr)
+	}
+	wordLength := allWords[0].Len()
+	for _, word := range allWords {
 		if word.Len() != wordLength {
 			return WordBank{}, fmt.Errorf("Words must all be the same length. Encountered word with length %v when expecting length %v.", word.Len(), wordLength)
 		}
-		allWords[i] = word
 	}
-	return WordBank{allWords, uint8(wordLength)}, nil
+	return WordBank{allWords, uint8(wordLength), normalizer, WordleRule{}}, nil
 }
 
 // WordLength provides the length of each word in the [WordBank].
@@ -79,7 +112,146 @@ func (wb *WordBank) WordLength() uint8 {
 	return wb.wordLength
 }
 
+// Normalizer provides the [Normalizer] that was used to construct the words in this bank.
+//
+// This should be used to convert any other strings to [Word]s before comparing them with words
+// in this bank.
+func (wb *WordBank) Normalizer() Normalizer {
+	return wb.normalizer
+}
+
+// FeedbackRule provides the rule used to give feedback on guesses for words in this bank.
+//
+// This is [WordleRule] unless changed with [WordBank.WithFeedbackRule].
+func (wb *WordBank) FeedbackRule() FeedbackRule {
+	if wb.rule == nil {
+		return WordleRule{}
+	}
+	return wb.rule
+}
+
+// WithFeedbackRule returns a copy of this bank that gives feedback with the given rule.
+//
+// The words are shared with this bank.
+func (wb *WordBank) WithFeedbackRule(rule FeedbackRule) WordBank {
+	copied := *wb
+	copied.rule = rule
+	return copied
+}
+
 // Words provides access to the words in this bank via a new [PossibleWords] object.
+//
+// The words are filtered with this bank's [FeedbackRule].
 func (wb *WordBank) Words() PossibleWords {
-	return initPossibleWords(wb.allWords)
+	words := initPossibleWords(wb.allWords, wb.FeedbackRule())
+	words.normalizer = wb.normalizer
+	return words
+}
+
+// MultiLengthWordBank provides read-only sets of words, grouped by word length.
+//
+// Each group can be accessed as a single-length [WordBank] in order to play games with words of
+// that length.
+type MultiLengthWordBank struct {
+	// Banks for each word length, ordered by increasing word length.
+	banks []WordBank
+}
+
+// MultiLengthWordBankFromReader constructs a new [MultiLengthWordBank] by reading words from the
+// given reader.
+//
+// The reader should provide one word per line. Each word will be normalized with the default
+// [Normalizer], i.e. trimmed and converted to lower case. Empty lines are skipped. At least one
+// word must be provided.
+//
+// Words may have any length up to 255 letters.
+func MultiLengthWordBankFromReader(r io.Reader) (MultiLengthWordBank, error) {
+	return MultiLengthWordBankFromReaderWithNormalizer(r, Normalizer{})
+}
+
+// MultiLengthWordBankFromReaderWithNormalizer is the same as [MultiLengthWordBankFromReader], but
+// normalizes words with the given [Normalizer].
+func MultiLengthWordBankFromReaderWithNormalizer(r io.Reader, normalizer Normalizer) (MultiLengthWordBank, error) {
+	wordsByLength := make(map[int][]Word)
+	err := scanWords(r, normalizer, func(word Word) error {
+		wordsByLength[word.Len()] = append(wordsByLength[word.Len()], word)
+		return nil
+	})
+	if err != nil {
+		return MultiLengthWordBank{}, err
+	}
+	return multiLengthWordBankFromMap(wordsByLength, normalizer)
+}
+
+// MultiLengthWordBankFromSlice constructs a new [MultiLengthWordBank] using the words from the
+// given slice.
+//
+// Each word will be normalized with the default [Normalizer], i.e. trimmed and converted to lower
+// case. Empty words are skipped. At least one word must be provided.
+//
+// Words may have any length up to 255 letters.
+func MultiLengthWordBankFromSlice(words []string) (MultiLengthWordBank, error) {
+	return MultiLengthWordBankFromSliceWithNormalizer(words, Normalizer{})
+}
+
+// MultiLengthWordBankFromSliceWithNormalizer is the same as [MultiLengthWordBankFromSlice], but
+// normalizes words with the given [Normalizer].
+func MultiLengthWordBankFromSliceWithNormalizer(words []string, normalizer Normalizer) (MultiLengthWordBank, error) {
+	wordsByLength := make(map[int][]Word)
+	for _, wordStr := range words {
+		word := normalizer.WordFromString(wordStr)
+		if word.Len() == 0 {
+			continue
+		}
+		wordsByLength[word.Len()] = append(wordsByLength[word.Len()], word)
+	}
+	return multiLengthWordBankFromMap(wordsByLength, normalizer)
+}
+
+func multiLengthWordBankFromMap(wordsByLength map[int][]Word, normalizer Normalizer) (MultiLengthWordBank, error) {
+	if len(wordsByLength) == 0 {
+		return MultiLengthWordBank{}, errors.New("At least one word must be provided.")
+	}
+	banks := make([]WordBank, 0, len(wordsByLength))
+	for length, words := range wordsByLength {
+		if length > math.MaxUint8 {
+			return MultiLengthWordBank{}, fmt.Errorf("Words can be at most %v letters long. Encountered word with length %v.", math.MaxUint8, length)
+		}
+		banks = append(banks, WordBank{slices.Clip(words), uint8(length), normalizer, WordleRule{}})
+	}
+	slices.SortFunc(banks, func(a, b WordBank) bool {
+		return a.wordLength < b.wordLength
+	})
+	return MultiLengthWordBank{banks}, nil
+}
+
+// WordLengths provides the distinct word lengths in this bank, in increasing order.
+func (mwb *MultiLengthWordBank) WordLengths() []uint8 {
+	lengths := make([]uint8, len(mwb.banks))
+	for i := range mwb.banks {
+		lengths[i] = mwb.banks[i].wordLength
+	}
+	return lengths
+}
+
+// ForLength provides a [WordBank] containing only the words of the given length.
+//
+// The returned bank remains valid for the lifetime of this [MultiLengthWordBank]. Returns an error
+// if there are no words of the given length.
+func (mwb *MultiLengthWordBank) ForLength(length uint8) (*WordBank, error) {
+	for i := range mwb.banks {
+		if mwb.banks[i].wordLength == length {
+			return &mwb.banks[i], nil
+		}
+	}
+	return nil, fmt.Errorf("There are no words of length %v. Available lengths: %v.", length, mwb.WordLengths())
+}
+
+// Len returns the total number of words in this bank, across all lengths.
+func (mwb *MultiLengthWordBank) Len() int {
+	total := 0
+	for i := range mwb.banks {
+		total += len(mwb.banks[i].allWords)
+	}
+	return total
 }
@@ -51,3 +51,60 @@ func TestWordBankWords(t *testing.T) {
 
 	assert.Equal(t, pw.Len(), 2)
 }
+
+func TestMultiLengthWordBankFromReaderWhenEmpty(t *testing.T) {
+	_, err := MultiLengthWordBankFromReader(strings.NewReader("  \n  "))
+	assert.Error(t, err, "At least one word must be provided.")
+}
+
+func TestMultiLengthWordBankFromReaderGroupsByLength(t *testing.T) {
+	bank, err := MultiLengthWordBankFromReader(strings.NewReader("abcd\n ab \nefgh\nabc\nijklm\nef£"))
+	assert.NilError(t, err)
+
+	assert.DeepEqual(t, bank.WordLengths(), []uint8{2, 3, 4, 5})
+	assert.Equal(t, bank.Len(), 6)
+
+	fours, err := bank.ForLength(4)
+	assert.NilError(t, err)
+	assert.Equal(t, fours.WordLength(), uint8(4))
+	pw := fours.Words()
+	assert.Equal(t, pw.Len(), 2)
+	assert.DeepEqual(t, pw.At(0), WordFromString("abcd"))
+	assert.DeepEqual(t, pw.At(1), WordFromString("efgh"))
+
+	threes, err := bank.ForLength(3)
+	assert.NilError(t, err)
+	pw = threes.Words()
+	assert.Equal(t, pw.Len(), 2)
+}
+
+func TestMultiLengthWordBankForMissingLength(t *testing.T) {
+	bank, err := MultiLengthWordBankFromSlice([]string{"abc", "abcd"})
+	assert.NilError(t, err)
+
+	_, err = bank.ForLength(5)
+	assert.Error(t, err, "There are no words of length 5. Available lengths: [3 4].")
+}
+
+func TestMultiLengthWordBankFromSliceWithEmptyList(t *testing.T) {
+	_, err := MultiLengthWordBankFromSlice([]string{})
+	assert.Error(t, err, "At least one word must be provided.")
+}
+
+func TestMultiLengthWordBankCanPlayEachLength(t *testing.T) {
+	bank, err := MultiLengthWordBankFromSlice([]string{"abc", "bcd", "cde", "abcd", "bcde", "cdef"})
+	assert.NilError(t, err)
+
+	for _, length := range bank.WordLengths() {
+		wb, err := bank.ForLength(length)
+		assert.NilError(t, err)
+		guesser := InitRandomGuesser(wb)
+		words := wb.Words()
+		objective := words.At(1)
+
+		result, err := PlayGameWithGuesser(objective, 3, &guesser)
+
+		assert.NilError(t, err)
+		assert.Equal(t, result.Status, GameSuccess)
+	}
+}
@@ -0,0 +1,240 @@
+package go_wordle_solver
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/exp/slices"
+)
+
+// Alphabet defines the set of letters that words may contain.
+//
+// The zero value allows any letter.
+type Alphabet struct {
+	letters []rune
+}
+
+// AlphabetFromString constructs an [Alphabet] containing each of the letters in the given string.
+func AlphabetFromString(letters string) Alphabet {
+	runes := []rune(letters)
+	slices.Sort(runes)
+	return Alphabet{slices.Compact(runes)}
+}
+
+// EnglishAlphabet contains the lower case letters from 'a' to 'z'.
+var EnglishAlphabet = AlphabetFromString("abcdefghijklmnopqrstuvwxyz")
+
+// Contains returns true iff the given letter is in this alphabet.
+func (a Alphabet) Contains(letter rune) bool {
+	if a.letters == nil {
+		return true
+	}
+	_, found := slices.BinarySearch(a.letters, letter)
+	return found
+}
+
+// String returns the letters in this alphabet.
+func (a Alphabet) String() string {
+	return string(a.letters)
+}
+
+// WordListProblemKind indicates what is wrong with a word in a word list.
+type WordListProblemKind int
+
+const (
+	// The word already appeared earlier in the list.
+	WordListProblemDuplicate WordListProblemKind = iota
+	// The word contains a letter that is not in the alphabet.
+	WordListProblemInvalidLetter
+	// The word is not the expected length.
+	WordListProblemWrongLength
+)
+
+// String converts [WordListProblemKind] into a readable string.
+func (k WordListProblemKind) String() string {
+	switch k {
+	case WordListProblemDuplicate:
+		return "duplicate"
+	case WordListProblemInvalidLetter:
+		return "invalid letter"
+	case WordListProblemWrongLength:
+		return "wrong length"
+	default:
+		return "invalid WordListProblemKind"
+	}
+}
+
+// WordListProblem describes a problem with a single line of a word list.
+type WordListProblem struct {
+	// The line number where the problem occurred, starting from 1.
+	Line int
+	// The normalized word on this line.
+	Word string
+	// What kind of problem this is.
+	Kind WordListProblemKind
+	// A readable description of the problem.
+	Detail string
+}
+
+// String converts [WordListProblem] into a readable string.
+func (p WordListProblem) String() string {
+	return fmt.Sprintf("line %v: %s \"%s\": %s", p.Line, p.Kind, p.Word, p.Detail)
+}
+
+// WordListOptions configures how a word list is validated.
+type WordListOptions struct {
+	// The normalizer used to convert each line into a word.
+	Normalizer Normalizer
+	// The letters that words may contain. The zero value allows any letter.
+	Alphabet Alphabet
+	// The length that all words must be. If zero, this is the length of the first word when
+	// loading a [WordBank], and any length is allowed when linting.
+	WordLength uint8
+	// If true, duplicate words are reported but otherwise ignored when loading a [WordBank].
+	// Otherwise, they are rejected.
+	AllowDuplicates bool
+}
+
+// WordListReport describes the contents of a word list, and any problems with it.
+type WordListReport struct {
+	// All problems found in the list, in line order.
+	Problems []WordListProblem
+	// The total number of lines in the list.
+	NumLines int
+	// The number of lines that are empty, or only contain a comment.
+	NumBlankOrComment int
+	// The number of unique words in the list, excluding words with problems.
+	NumWords int
+	// The number of unique words of each length, excluding words with problems.
+	NumWordsPerLength map[int]int
+	// The number of times each letter appears in the unique words, excluding words with
+	// problems.
+	LetterCounts map[rune]int
+
+	// The valid words, and the line that each was found on.
+	words     []Word
+	wordLines []int
+}
+
+// WordListError is returned when a word list can't be loaded due to problems with its contents.
+type WordListError struct {
+	// The problems that caused the error.
+	Problems []WordListProblem
+}
+
+// Error describes the first problem in the list.
+func (e *WordListError) Error() string {
+	if len(e.Problems) == 1 {
+		return fmt.Sprintf("Invalid word list. %s.", e.Problems[0])
+	}
+	return fmt.Sprintf("Invalid word list. %s (and %v more problems).", e.Problems[0], len(e.Problems)-1)
+}
+
+// LintWordList reads a word list from the given reader, and reports any problems with it along
+// with statistics about its contents.
+//
+// The reader should provide one word per line. Each word is normalized with the given options'
+// [Normalizer]. Empty lines are skipped, as is any text after a '#', so that lists can contain
+// comments.
+//
+// This only returns an error if the reader fails.
+func LintWordList(r io.Reader, options WordListOptions) (WordListReport, error) {
+	report := WordListReport{
+		NumWordsPerLength: make(map[int]int),
+		LetterCounts:      make(map[rune]int),
+		words:             make([]Word, 0, defaultWordBuffer),
+	}
+	firstLineForWord := make(map[string]int)
+	s := bufio.NewScanner(r)
+	for ok := s.Scan(); ok; ok = s.Scan() {
+		report.NumLines++
+		line := s.Text()
+		if commentStart := strings.IndexRune(line, '#'); commentStart >= 0 {
+			line = line[:commentStart]
+		}
+		wordStr := options.Normalizer.Normalize(line)
+		if wordStr == "" {
+			report.NumBlankOrComment++
+			continue
+		}
+		word := WordFromString(wordStr)
+		problem := WordListProblem{Line: report.NumLines, Word: wordStr}
+		if firstLine, isPresent := firstLineForWord[wordStr]; isPresent {
+			problem.Kind = WordListProblemDuplicate
+			problem.Detail = fmt.Sprintf("first seen on line %v", firstLine)
+			report.Problems = append(report.Problems, problem)
+			continue
+		}
+		firstLineForWord[wordStr] = report.NumLines
+		if options.WordLength != 0 && word.Len() != int(options.WordLength) {
+			problem.Kind = WordListProblemWrongLength
+			problem.Detail = fmt.Sprintf("has length %v instead of %v", word.Len(), options.WordLength)
+			report.Problems = append(report.Problems, problem)
+			continue
+		}
+		if i := strings.IndexFunc(wordStr, func(letter rune) bool { return !options.Alphabet.Contains(letter) }); i >= 0 {
+			problem.Kind = WordListProblemInvalidLetter
+			problem.Detail = fmt.Sprintf("'%c' is not in the alphabet", []rune(wordStr[i:])[0])
+			report.Problems = append(report.Problems, problem)
+			continue
+		}
+		report.NumWords++
+		report.NumWordsPerLength[word.Len()]++
+		word.AllLetters(func(letter rune) bool {
+			report.LetterCounts[letter]++
+			return true
+		})
+		report.words = append(report.words, word)
+		report.wordLines = append(report.wordLines, report.NumLines)
+	}
+	return report, s.Err()
+}
+
+// WordBankFromReaderValidated constructs a new [WordBank] by reading words from the given reader,
+// validating each word with the given options.
+//
+// See [LintWordList] for details about the expected format. If any problems are found, this
+// returns a [*WordListError] that includes the line number of each problem. Duplicate words are
+// only permitted if the options allow it, in which case only the first instance is kept.
+//
+// All words must be the same length. At least one word must be provided.
+func WordBankFromReaderValidated(r io.Reader, options WordListOptions) (WordBank, error) {
+	report, err := LintWordList(r, options)
+	if err != nil {
+		return WordBank{}, err
+	}
+	problems := report.Problems
+	if options.AllowDuplicates {
+		problems = filter(problems, func(p WordListProblem) bool {
+			return p.Kind != WordListProblemDuplicate
+		})
+	}
+	words := report.words
+	if options.WordLength == 0 && len(words) > 0 {
+		// Require all words to be the length of the first word.
+		options.WordLength = uint8(words[0].Len())
+		for i, word := range words {
+			if word.Len() != int(options.WordLength) {
+				problems = append(problems, WordListProblem{
+					Line:   report.wordLines[i],
+					Word:   word.String(),
+					Kind:   WordListProblemWrongLength,
+					Detail: fmt.Sprintf("has length %v instead of %v", word.Len(), options.WordLength),
+				})
+			}
+		}
+		slices.SortStableFunc(problems, func(a, b WordListProblem) bool {
+			return a.Line < b.Line
+		})
+	}
+	if len(problems) > 0 {
+		return WordBank{}, &WordListError{problems}
+	}
+	if len(words) == 0 {
+		return WordBank{}, errors.New("At least one word must be provided.")
+	}
+	return WordBank{slices.Clip(words), options.WordLength, options.Normalizer, WordleRule{}}, nil
+}
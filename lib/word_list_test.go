@@ -0,0 +1,98 @@
+package go_wordle_solver
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestAlphabetContains(t *testing.T) {
+	assert.Assert(t, EnglishAlphabet.Contains('a'))
+	assert.Assert(t, EnglishAlphabet.Contains('z'))
+	assert.Assert(t, !EnglishAlphabet.Contains('A'))
+	assert.Assert(t, !EnglishAlphabet.Contains('é'))
+
+	var anyLetters Alphabet
+	assert.Assert(t, anyLetters.Contains('é'))
+
+	alphabet := AlphabetFromString("cabbage")
+	assert.Equal(t, alphabet.String(), "abceg")
+}
+
+func TestLintWordList(t *testing.T) {
+	list := `# A comment
+abc
+bcd # A trailing comment
+
+ABC
+ab£
+abcd
+`
+	report, err := LintWordList(strings.NewReader(list), WordListOptions{Alphabet: EnglishAlphabet})
+
+	assert.NilError(t, err)
+	assert.DeepEqual(t, report.Problems, []WordListProblem{
+		{Line: 5, Word: "abc", Kind: WordListProblemDuplicate, Detail: "first seen on line 2"},
+		{Line: 6, Word: "ab£", Kind: WordListProblemInvalidLetter, Detail: "'£' is not in the alphabet"},
+	})
+	assert.Equal(t, report.NumLines, 7)
+	assert.Equal(t, report.NumBlankOrComment, 2)
+	assert.Equal(t, report.NumWords, 3)
+	assert.DeepEqual(t, report.NumWordsPerLength, map[int]int{3: 2, 4: 1})
+	assert.DeepEqual(t, report.LetterCounts, map[rune]int{'a': 2, 'b': 3, 'c': 3, 'd': 2})
+}
+
+func TestLintWordListWithWordLength(t *testing.T) {
+	report, err := LintWordList(strings.NewReader("abc\nabcd\nab"), WordListOptions{WordLength: 3})
+
+	assert.NilError(t, err)
+	assert.DeepEqual(t, report.Problems, []WordListProblem{
+		{Line: 2, Word: "abcd", Kind: WordListProblemWrongLength, Detail: "has length 4 instead of 3"},
+		{Line: 3, Word: "ab", Kind: WordListProblemWrongLength, Detail: "has length 2 instead of 3"},
+	})
+}
+
+func TestWordBankFromReaderValidatedValidWords(t *testing.T) {
+	bank, err := WordBankFromReaderValidated(
+		strings.NewReader("# Three letter words\nabc\n bcd \n\ncde # last"),
+		WordListOptions{Alphabet: EnglishAlphabet})
+
+	assert.NilError(t, err)
+	assert.Equal(t, bank.WordLength(), uint8(3))
+	pw := bank.Words()
+	assert.Equal(t, pw.Len(), 3)
+	assert.DeepEqual(t, pw.At(2), WordFromString("cde"))
+}
+
+func TestWordBankFromReaderValidatedReportsLineNumbers(t *testing.T) {
+	_, err := WordBankFromReaderValidated(
+		strings.NewReader("# Three letter words\nabc\nbcd\nefgh\nab1"),
+		WordListOptions{Alphabet: EnglishAlphabet})
+
+	assert.Error(t, err, `Invalid word list. line 4: wrong length "efgh": has length 4 instead of 3 (and 1 more problems).`)
+	var listErr *WordListError
+	assert.Assert(t, errors.As(err, &listErr))
+	assert.DeepEqual(t, listErr.Problems, []WordListProblem{
+		{Line: 4, Word: "efgh", Kind: WordListProblemWrongLength, Detail: "has length 4 instead of 3"},
+		{Line: 5, Word: "ab1", Kind: WordListProblemInvalidLetter, Detail: "'1' is not in the alphabet"},
+	})
+}
+
+func TestWordBankFromReaderValidatedDuplicates(t *testing.T) {
+	list := "abc\nbcd\nabc"
+
+	_, err := WordBankFromReaderValidated(strings.NewReader(list), WordListOptions{})
+	assert.Error(t, err, `Invalid word list. line 3: duplicate "abc": first seen on line 1.`)
+
+	bank, err := WordBankFromReaderValidated(strings.NewReader(list), WordListOptions{AllowDuplicates: true})
+	assert.NilError(t, err)
+	pw := bank.Words()
+	assert.Equal(t, pw.Len(), 2)
+}
+
+func TestWordBankFromReaderValidatedWhenEmpty(t *testing.T) {
+	_, err := WordBankFromReaderValidated(strings.NewReader("# Nothing here\n\n"), WordListOptions{})
+	assert.Error(t, err, "At least one word must be provided.")
+}
@@ -31,3 +31,57 @@ func TestString(t *testing.T) {
 	w := WordFromString("hello")
 	assert.Equal(t, w.String(), "hello")
 }
+
+func TestWordFromStringPacksShortAsciiWords(t *testing.T) {
+	assert.Assert(t, WordFromString("hello").isPacked())
+	assert.Assert(t, WordFromString("abcdefgh").isPacked())
+	assert.Assert(t, !WordFromString("abcdefghi").isPacked())
+	assert.Assert(t, !WordFromString("ab£").isPacked())
+}
+
+func TestPackedWordMatchesRunes(t *testing.T) {
+	packed := WordFromString("hello")
+	runes := wordFromRunes([]rune("hello"))
+
+	assert.Equal(t, packed.Len(), runes.Len())
+	assert.Equal(t, packed.String(), runes.String())
+	for i := 0; i < packed.Len(); i++ {
+		assert.Equal(t, packed.At(i), runes.At(i))
+	}
+}
+
+func TestEqual(t *testing.T) {
+	assert.Assert(t, WordFromString("hello").Equal(WordFromString("hello")))
+	assert.Assert(t, WordFromString("hello").Equal(wordFromRunes([]rune("hello"))))
+	assert.Assert(t, wordFromRunes([]rune("hello")).Equal(WordFromString("hello")))
+	assert.Assert(t, WordFromString("ab£").Equal(WordFromString("ab£")))
+	assert.Assert(t, !WordFromString("hello").Equal(WordFromString("hellp")))
+	assert.Assert(t, !WordFromString("hello").Equal(WordFromString("hell")))
+	assert.Assert(t, !WordFromString("abc").Equal(WordFromString("ab£")))
+}
+
+func TestAllLetters(t *testing.T) {
+	isL := func(r rune) bool { return r == 'l' }
+	notZ := func(r rune) bool { return r != 'z' }
+
+	assert.Assert(t, !WordFromString("hello").AllLetters(isL))
+	assert.Assert(t, WordFromString("hello").AllLetters(notZ))
+	assert.Assert(t, WordFromString("ll").AllLetters(isL))
+	assert.Assert(t, WordFromString("ll£").AllLetters(notZ))
+}
+
+func BenchmarkEqualPacked(b *testing.B) {
+	w1 := WordFromString("hello")
+	w2 := WordFromString("hellp")
+	for n := 0; n < b.N; n++ {
+		w1.Equal(w2)
+	}
+}
+
+func BenchmarkEqualRunes(b *testing.B) {
+	w1 := wordFromRunes([]rune("hello"))
+	w2 := wordFromRunes([]rune("hellp"))
+	for n := 0; n < b.N; n++ {
+		w1.Equal(w2)
+	}
+}
@@ -0,0 +1,426 @@
+package go_wordle_solver
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/exp/maps"
+)
+
+// This file supports Xordle, a Wordle variant with two hidden words that have no letters in
+// common. Each letter's result is the best of its results for either word, so a letter is correct
+// if it's correct for either word, and otherwise present if it's in either word.
+//
+// The game is won once both words have been guessed.
+
+// IsValidXordlePair returns true iff the two words could be the hidden words in a game of Xordle,
+// i.e. they are the same length and have no letters in common.
+func IsValidXordlePair(first, second Word) bool {
+	if first.Len() != second.Len() {
+		return false
+	}
+	length := first.Len()
+	for i := 0; i < length; i++ {
+		letter := first.At(i)
+		for j := 0; j < length; j++ {
+			if second.At(j) == letter {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// GetXordleResultForGuess determines the result of the given guess when the hidden words are first
+// and second.
+//
+// Returns an error if the words aren't a valid pair (see [IsValidXordlePair]), or if the guess is
+// a different length.
+func GetXordleResultForGuess(first, second, guess Word) (GuessResult, error) {
+	if !IsValidXordlePair(first, second) {
+		return GuessResult{}, fmt.Errorf("The hidden words (%s and %s) must be the same length and have no letters in common.", first, second)
+	}
+	firstResult, err := GetResultForGuess(first, guess)
+	if err != nil {
+		return GuessResult{}, err
+	}
+	secondResult, err := GetResultForGuess(second, guess)
+	if err != nil {
+		return GuessResult{}, err
+	}
+	for i, lr := range secondResult.Results {
+		// Lower results are better.
+		if lr < firstResult.Results[i] {
+			firstResult.Results[i] = lr
+		}
+	}
+	return firstResult, nil
+}
+
+// XordlePair is a pair of hidden words for Xordle.
+type XordlePair struct {
+	First  Word
+	Second Word
+}
+
+// XordlePairs provides the possible pairs of hidden words for a Xordle puzzle.
+//
+// Each pair is two words from a [WordBank] that have no letters in common.
+type XordlePairs struct {
+	words []Word
+	pairs []xordlePairIndices
+}
+
+// The indices of a pair's words in [XordlePairs.words], where first < second.
+type xordlePairIndices struct {
+	first  int32
+	second int32
+}
+
+// InitXordlePairs constructs the possible pairs of hidden words from the given bank.
+//
+// Note that the number of pairs grows quadratically with the number of words. Returns an error if
+// the words are longer than [MaxLettersInCompressedGuessResult].
+func InitXordlePairs(bank *WordBank) (XordlePairs, error) {
+	if bank.WordLength() > MaxLettersInCompressedGuessResult {
+		return XordlePairs{}, fmt.Errorf("Xordle is only supported for words with up to %v letters.", MaxLettersInCompressedGuessResult)
+	}
+	words := bank.Words().words
+	letterSets := letterBitSets(words)
+	var pairs []xordlePairIndices
+	for i := range words {
+		for j := i + 1; j < len(words); j++ {
+			if !letterSets[i].intersects(letterSets[j]) {
+				pairs = append(pairs, xordlePairIndices{int32(i), int32(j)})
+			}
+		}
+	}
+	return XordlePairs{words, pairs}, nil
+}
+
+// Copy copies these pairs.
+func (self *XordlePairs) Copy() XordlePairs {
+	return XordlePairs{
+		self.words,
+		append([]xordlePairIndices(nil), self.pairs...),
+	}
+}
+
+// Len returns the number of possible pairs.
+func (self *XordlePairs) Len() int {
+	return len(self.pairs)
+}
+
+// At retrieves the pair at the given index.
+func (self *XordlePairs) At(i int) XordlePair {
+	pair := self.pairs[i]
+	return XordlePair{self.words[pair.first], self.words[pair.second]}
+}
+
+// Words returns each word that is in at least one possible pair.
+func (self *XordlePairs) Words() PossibleWords {
+	isPossible := make([]bool, len(self.words))
+	for _, pair := range self.pairs {
+		isPossible[pair.first] = true
+		isPossible[pair.second] = true
+	}
+	var words []Word
+	for i, word := range self.words {
+		if isPossible[i] {
+			words = append(words, word)
+		}
+	}
+	return PossibleWords{
+		words:        words,
+		restrictions: InitWordRestrictions(uint8(self.words[0].Len())),
+	}
+}
+
+// Filter keeps only the pairs that would give the same result for the guess.
+//
+// If no pairs would give this result, an error is returned and the pairs are unchanged.
+func (self *XordlePairs) Filter(result *GuessResult) error {
+	length := self.words[0].Len()
+	if result.Guess.Len() != length || len(result.Results) != length {
+		return fmt.Errorf("The guess (%s) and results must have %v letters.", result.Guess, length)
+	}
+	want, err := CompressResults(result.Results)
+	if err != nil {
+		return err
+	}
+	wordResults, err := self.compressedResultsForGuess(result.Guess)
+	if err != nil {
+		return err
+	}
+	matches := func(pair xordlePairIndices) bool {
+		return combineCompressedResults(wordResults[pair.first], wordResults[pair.second], length) == want
+	}
+	if !anyValue(self.pairs, matches) {
+		return fmt.Errorf("No pairs of words would give the result %s for %s.", LetterResultsString(result.Results), result.Guess)
+	}
+	self.pairs = filter(self.pairs, matches)
+	return nil
+}
+
+// compressedResultsForGuess returns the compressed result of the guess for each word, indexed like
+// self.words. Only the words in at least one possible pair are computed.
+func (self *XordlePairs) compressedResultsForGuess(guess Word) ([]CompressedGuessResult, error) {
+	wordResults := make([]CompressedGuessResult, len(self.words))
+	isComputed := make([]bool, len(self.words))
+	compute := func(i int32) error {
+		if isComputed[i] {
+			return nil
+		}
+		wordResult, err := GetResultForGuess(self.words[i], guess)
+		if err != nil {
+			return err
+		}
+		wordResults[i], err = CompressResults(wordResult.Results)
+		isComputed[i] = true
+		return err
+	}
+	for _, pair := range self.pairs {
+		if err := compute(pair.first); err != nil {
+			return nil, err
+		}
+		if err := compute(pair.second); err != nil {
+			return nil, err
+		}
+	}
+	return wordResults, nil
+}
+
+// combineCompressedResults returns the compressed result that is the best of the two results for
+// each letter.
+func combineCompressedResults(a, b CompressedGuessResult, length int) CompressedGuessResult {
+	combined := CompressedGuessResult(0)
+	place := CompressedGuessResult(1)
+	for i := 0; i < length; i++ {
+		aDigit := (a / place) % numCompressedLetterResults
+		bDigit := (b / place) % numCompressedLetterResults
+		if bDigit < aDigit {
+			aDigit = bDigit
+		}
+		combined += aDigit * place
+		place *= numCompressedLetterResults
+	}
+	return combined
+}
+
+// letterBitSet is a set of letters, where each letter is assigned a bit.
+type letterBitSet []uint64
+
+func (self letterBitSet) intersects(other letterBitSet) bool {
+	for i, bits := range self {
+		if bits&other[i] != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// letterBitSets returns the set of letters in each word.
+func letterBitSets(words []Word) []letterBitSet {
+	letterBits := make(map[rune]int)
+	for _, word := range words {
+		for i := 0; i < word.Len(); i++ {
+			if _, isPresent := letterBits[word.At(i)]; !isPresent {
+				letterBits[word.At(i)] = len(letterBits)
+			}
+		}
+	}
+	numBlocks := (len(letterBits) + 63) / 64
+	sets := make([]letterBitSet, len(words))
+	for i, word := range words {
+		sets[i] = make(letterBitSet, numBlocks)
+		for j := 0; j < word.Len(); j++ {
+			bit := letterBits[word.At(j)]
+			sets[i][bit/64] |= 1 << (bit % 64)
+		}
+	}
+	return sets
+}
+
+// PlayXordleGameWithGuesser attempts to guess both of the given words within the maximum number of
+// guesses, using the given [Guesser].
+//
+// A [XordleGuesser] should be used, since other guessers expect results for a single word.
+func PlayXordleGameWithGuesser[G Guesser](
+	first, second Word,
+	maxNumGuesses int,
+	guesser G,
+) (GameResult, error) {
+	normalizer := guesser.PossibleWords().Normalizer()
+	first, second = normalizer.NormalizeWord(first), normalizer.NormalizeWord(second)
+	if !IsValidXordlePair(first, second) {
+		return GameResult{}, fmt.Errorf("The hidden words (%s and %s) must be the same length and have no letters in common.", first, second)
+	}
+	guesser.Reset()
+	turns := make([]TurnData, 0, maxNumGuesses)
+	foundFirst, foundSecond := false, false
+	for i := 0; i < maxNumGuesses; i++ {
+		maybeGuess := guesser.SelectNextGuess()
+		if !maybeGuess.HasValue() {
+			return GameResult{}, errors.New("No more valid guesses.")
+		}
+		guess := maybeGuess.Value()
+		numPossibleWordsBeforeGuess := guesser.PossibleWords().Len()
+		result, err := GetXordleResultForGuess(first, second, guess)
+		if err != nil {
+			return GameResult{}, fmt.Errorf("Couldn't get result for guess %s, error: %s", guess, err)
+		}
+		turns = append(turns, TurnData{
+			guess,
+			uint(numPossibleWordsBeforeGuess),
+		})
+		foundFirst = foundFirst || guess.Equal(first)
+		foundSecond = foundSecond || guess.Equal(second)
+		if foundFirst && foundSecond {
+			return GameResult{GameSuccess, turns}, nil
+		}
+		err = guesser.Update(&result)
+		if err != nil {
+			panic(fmt.Sprintf("Failed to update the guesser. Error: %s", err))
+		}
+	}
+	return GameResult{GameFailure, turns}, nil
+}
+
+// XordleGuesser guesses both hidden words in Xordle.
+//
+// It tracks the possible pairs of hidden words, and selects the word that maximizes the score from
+// the [WordScorer] implementation, where the scorer's possible words are the unguessed words in any
+// possible pair.
+type XordleGuesser[S WordScorer] struct {
+	bank *WordBank
+	// All the pairs, before any results.
+	allPairs       *XordlePairs
+	pairs          XordlePairs
+	possibleWords  PossibleWords
+	scorer         S
+	guessMode      GuessMode
+	unguessedWords PossibleWords
+	guessed        []Word
+}
+
+// InitXordleGuesser constructs a [XordleGuesser] for the given bank, scorer and mode.
+//
+// This finds every possible pair of words in the bank, so it can be expensive for large banks. See
+// [InitXordlePairs].
+func InitXordleGuesser[S WordScorer](bank *WordBank, scorer S, mode GuessMode) (XordleGuesser[S], error) {
+	pairs, err := InitXordlePairs(bank)
+	if err != nil {
+		return XordleGuesser[S]{}, err
+	}
+	return XordleGuesser[S]{
+		bank:           bank,
+		allPairs:       &pairs,
+		pairs:          pairs.Copy(),
+		possibleWords:  pairs.Words(),
+		scorer:         scorer,
+		guessMode:      mode,
+		unguessedWords: bank.Words(),
+		guessed:        nil,
+	}, nil
+}
+
+// Copy copies the [XordleGuesser].
+func (self *XordleGuesser[S]) Copy() Guesser {
+	return &XordleGuesser[S]{
+		self.bank,
+		self.allPairs,
+		self.pairs.Copy(),
+		self.possibleWords.Copy(),
+		self.scorer.Copy().(S),
+		self.guessMode,
+		self.unguessedWords.Copy(),
+		append([]Word(nil), self.guessed...),
+	}
+}
+
+// Reset resets the [XordleGuesser] so it can be used to solve a new puzzle.
+func (self *XordleGuesser[S]) Reset() {
+	self.pairs = self.allPairs.Copy()
+	self.possibleWords = self.pairs.Words()
+	self.unguessedWords = self.bank.Words()
+	self.guessed = nil
+	self.scorer.Reset(&self.possibleWords)
+}
+
+// Update updates the possible pairs based on the given result.
+//
+// If no pairs would give this result, this returns an error and the guesser is unchanged.
+func (self *XordleGuesser[S]) Update(result *GuessResult) error {
+	result = self.bank.Normalizer().normalizeResult(result)
+	previousPairs := self.pairs.Copy()
+	if err := self.pairs.Filter(result); err != nil {
+		return err
+	}
+	// The possible words are rebuilt, so the previous words are left as they were.
+	previousWords := self.possibleWords
+	self.possibleWords = self.pairs.Words()
+	for _, word := range self.guessed {
+		self.possibleWords.Remove(word)
+	}
+	self.possibleWords.Remove(result.Guess)
+	if err := self.scorer.Update(result.Guess, &self.possibleWords); err != nil {
+		self.pairs = previousPairs
+		self.possibleWords = previousWords
+		return err
+	}
+	self.guessed = append(self.guessed, result.Guess)
+	self.unguessedWords.Remove(result.Guess)
+	return nil
+}
+
+// The maximum number of pairs for which [XordleGuesser] scores guesses by how they split the pairs.
+const maxPairsForPairScoring = 1000
+
+// SelectNextGuess returns the guess that maximizes the owned [WordScorer]'s score.
+//
+// Once there are few enough possible pairs, this instead returns the guess that minimizes the
+// expected number of pairs after the next result, since the scorer only considers one word at a
+// time.
+//
+// If there are no more possible words, this returns an empty optional.
+func (self *XordleGuesser[S]) SelectNextGuess() Optional[Word] {
+	if self.pairs.Len() <= maxPairsForPairScoring {
+		return selectMaxScoreGuess(&self.possibleWords, &self.unguessedWords, self.guessMode, self.scoreByPairs, 1)
+	}
+	setScorerCandidates(self.scorer, maxScoreCandidates(&self.possibleWords, &self.unguessedWords, self.guessMode), 1)
+	return selectMaxScoreGuess(&self.possibleWords, &self.unguessedWords, self.guessMode, self.scorer.ScoreWord, 1)
+}
+
+// scoreByPairs scores the guess by how it splits the possible pairs, where a higher score is a
+// better guess.
+//
+// If m_r pairs would give result r, then the expected number of pairs after the result is
+// proportional to Σ m_r², so the score is -Σ m_r².
+//
+// Each word's result is computed once, and combined for each pair as in [XordlePairs.Filter].
+func (self *XordleGuesser[S]) scoreByPairs(guess Word) int64 {
+	wordResults, err := self.pairs.compressedResultsForGuess(guess)
+	if err != nil {
+		panic(fmt.Sprintf("Failed to score word: %s, error: %s", guess, err))
+	}
+	length := guess.Len()
+	counts := make(map[CompressedGuessResult]int64)
+	for _, pair := range self.pairs.pairs {
+		counts[combineCompressedResults(wordResults[pair.first], wordResults[pair.second], length)]++
+	}
+	return -sumOfSquares(maps.Values(counts))
+}
+
+// PossibleWords provides a pointer to the unguessed words that are in at least one possible pair.
+//
+// This remains valid until the next call to [XordleGuesser.Update] or [XordleGuesser.Reset].
+func (self *XordleGuesser[S]) PossibleWords() *PossibleWords {
+	return &self.possibleWords
+}
+
+// Pairs provides a pointer to the possible pairs of hidden words.
+//
+// This remains valid until the next call to [XordleGuesser.Update] or [XordleGuesser.Reset].
+func (self *XordleGuesser[S]) Pairs() *XordlePairs {
+	return &self.pairs
+}
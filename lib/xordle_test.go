@@ -0,0 +1,203 @@
+package go_wordle_solver
+
+import (
+	"testing"
+
+	"gotest.tools/v3/assert"
+)
+
+func TestIsValidXordlePair(t *testing.T) {
+	assert.Assert(t, IsValidXordlePair(WordFromString("fight"), WordFromString("crane")))
+	assert.Assert(t, !IsValidXordlePair(WordFromString("fight"), WordFromString("taken")))
+	assert.Assert(t, !IsValidXordlePair(WordFromString("abc"), WordFromString("defg")))
+}
+
+func TestGetXordleResultForGuess(t *testing.T) {
+	tests := []struct {
+		guess string
+		want  string
+	}{
+		{"eight", "ygggg"},
+		{"crane", "ggggg"},
+		{"fiery", "ggyy-"},
+		{"sassy", "-y---"},
+		{"tacit", "-yyyg"},
+	}
+	for _, test := range tests {
+		result, err := GetXordleResultForGuess(WordFromString("fight"), WordFromString("crane"), WordFromString(test.guess))
+
+		assert.NilError(t, err)
+		assert.Equal(t, LetterResultsString(result.Results), test.want, test.guess)
+	}
+}
+
+func TestGetXordleResultForGuessInvalidPair(t *testing.T) {
+	_, err := GetXordleResultForGuess(WordFromString("fight"), WordFromString("taken"), WordFromString("eight"))
+
+	assert.Error(t, err, "The hidden words (fight and taken) must be the same length and have no letters in common.")
+}
+
+func TestXordlePairs(t *testing.T) {
+	bank, err := WordBankFromSlice([]string{"abc", "def", "bcd", "xyz"})
+	assert.NilError(t, err)
+
+	pairs, err := InitXordlePairs(&bank)
+
+	assert.NilError(t, err)
+	var got []string
+	for i := 0; i < pairs.Len(); i++ {
+		pair := pairs.At(i)
+		got = append(got, pair.First.String()+"+"+pair.Second.String())
+	}
+	assert.DeepEqual(t, got, []string{"abc+def", "abc+xyz", "def+xyz", "bcd+xyz"})
+	words := pairs.Words()
+	assert.Equal(t, words.Len(), 4)
+}
+
+func TestXordlePairsFilterMatchesFeedback(t *testing.T) {
+	bank, err := BuiltinWordBank("1000-improved-shuffled")
+	assert.NilError(t, err)
+	allPairs, err := InitXordlePairs(&bank)
+	assert.NilError(t, err)
+	for _, test := range []struct{ first, second string }{
+		{allPairs.At(0).First.String(), allPairs.At(0).Second.String()},
+		{allPairs.At(allPairs.Len() / 2).First.String(), allPairs.At(allPairs.Len() / 2).Second.String()},
+	} {
+		pairs := allPairs.Copy()
+		var results []GuessResult
+		for _, guess := range []string{"sassy", "eerie", test.first} {
+			result, err := GetXordleResultForGuess(WordFromString(test.first), WordFromString(test.second), WordFromString(guess))
+			assert.NilError(t, err)
+			results = append(results, result)
+
+			assert.NilError(t, pairs.Filter(&result))
+
+			numWant := 0
+			for i := 0; i < allPairs.Len(); i++ {
+				pair := allPairs.At(i)
+				if hasSameXordleFeedback(t, pair, results) {
+					numWant++
+				}
+			}
+			assert.Equal(t, pairs.Len(), numWant)
+			for i := 0; i < pairs.Len(); i++ {
+				assert.Assert(t, hasSameXordleFeedback(t, pairs.At(i), results))
+			}
+		}
+	}
+}
+
+func hasSameXordleFeedback(t *testing.T, pair XordlePair, results []GuessResult) bool {
+	for _, result := range results {
+		feedback, err := GetXordleResultForGuess(pair.First, pair.Second, result.Guess)
+		assert.NilError(t, err)
+		if LetterResultsString(feedback.Results) != LetterResultsString(result.Results) {
+			return false
+		}
+	}
+	return true
+}
+
+func TestXordlePairsFilterWithNoMatches(t *testing.T) {
+	bank, err := WordBankFromSlice([]string{"abc", "def", "bcd", "xyz"})
+	assert.NilError(t, err)
+	pairs, err := InitXordlePairs(&bank)
+	assert.NilError(t, err)
+	result := guessResult(t, "abc", "yyy")
+
+	err = pairs.Filter(&result)
+
+	assert.Error(t, err, "No pairs of words would give the result yyy for abc.")
+	assert.Equal(t, pairs.Len(), 4)
+}
+
+func TestPlayXordleGameWithGuesser(t *testing.T) {
+	full, err := BuiltinWordBank("1000-improved-shuffled")
+	assert.NilError(t, err)
+	words := full.Words()
+	bankWords := make([]string, 300)
+	for i := range bankWords {
+		bankWords[i] = words.At(i).String()
+	}
+	bank, err := WordBankFromSlice(bankWords)
+	assert.NilError(t, err)
+	scorer, err := InitMaxEliminationsScorer(&bank)
+	assert.NilError(t, err)
+	guesser, err := InitXordleGuesser(&bank, &scorer, GuessModeAll)
+	assert.NilError(t, err)
+	pairs := guesser.Pairs().Copy()
+
+	for i := 0; i < pairs.Len(); i += pairs.Len() / 20 {
+		pair := pairs.At(i)
+
+		result, err := PlayXordleGameWithGuesser(pair.First, pair.Second, 20, &guesser)
+
+		assert.NilError(t, err)
+		assert.Equal(t, result.Status, GameSuccess, "%s and %s", pair.First, pair.Second)
+		var guesses []string
+		for _, turn := range result.Turns {
+			guesses = append(guesses, turn.Guess.String())
+		}
+		assert.Assert(t, len(result.Turns) <= 10, "guesses: %v", guesses)
+	}
+}
+
+func TestXordleGuesserFindsSecondWordAfterFirst(t *testing.T) {
+	bank, err := WordBankFromSlice([]string{"abc", "def", "bcd", "xyz"})
+	assert.NilError(t, err)
+	scorer, err := InitMaxEliminationsScorer(&bank)
+	assert.NilError(t, err)
+	guesser, err := InitXordleGuesser(&bank, &scorer, GuessModePossible)
+	assert.NilError(t, err)
+	result, err := GetXordleResultForGuess(WordFromString("def"), WordFromString("xyz"), WordFromString("def"))
+	assert.NilError(t, err)
+
+	assert.NilError(t, guesser.Update(&result))
+
+	assert.Equal(t, guesser.Pairs().Len(), 2)
+	assert.Equal(t, guesser.PossibleWords().Len(), 2)
+	guess := guesser.SelectNextGuess()
+	assert.Assert(t, guess.HasValue())
+	assert.Assert(t, !guess.Value().Equal(WordFromString("def")))
+}
+
+func TestXordleGuesserScoreByPairs(t *testing.T) {
+	bank := firstWordsBank(t, 60)
+	scorer, err := InitMaxEliminationsScorer(&bank)
+	assert.NilError(t, err)
+	guesser, err := InitXordleGuesser(&bank, &scorer, GuessModeAll)
+	assert.NilError(t, err)
+	words := bank.Words()
+
+	for i := 0; i < words.Len(); i += 7 {
+		guess := words.At(i)
+		counts := make(map[CompressedGuessResult]int64)
+		for p := 0; p < guesser.Pairs().Len(); p++ {
+			pair := guesser.Pairs().At(p)
+			result, err := GetXordleResultForGuess(pair.First, pair.Second, guess)
+			assert.NilError(t, err)
+			compressed, err := CompressResults(result.Results)
+			assert.NilError(t, err)
+			counts[compressed]++
+		}
+		want := int64(0)
+		for _, count := range counts {
+			want -= count * count
+		}
+
+		assert.Equal(t, guesser.scoreByPairs(guess), want, guess.String())
+	}
+}
+
+func TestPlayXordleGameWithGuesserInvalidPair(t *testing.T) {
+	bank, err := WordBankFromSlice([]string{"abc", "def", "bcd", "xyz"})
+	assert.NilError(t, err)
+	scorer, err := InitMaxEliminationsScorer(&bank)
+	assert.NilError(t, err)
+	guesser, err := InitXordleGuesser(&bank, &scorer, GuessModeAll)
+	assert.NilError(t, err)
+
+	_, err = PlayXordleGameWithGuesser(WordFromString("abc"), WordFromString("bcd"), 10, &guesser)
+
+	assert.Error(t, err, "The hidden words (abc and bcd) must be the same length and have no letters in common.")
+}